@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/wardviaene/meetparser/pkg/parser"
+)
+
+// explain prints how the parser read a line or the lines of an event:
+// parser explain -filename <filename> -line 1234
+// parser explain -filename <filename> -event 57
+func explain(args []string) {
+	flags := flag.NewFlagSet("explain", flag.ExitOnError)
+	var filename string
+	var line int
+	var event string
+	var layout bool
+	var positions bool
+	var jsonOutput bool
+	var mode string
+	var format string
+	flags.StringVar(&filename, "filename", "", "parse filename")
+	flags.IntVar(&line, "line", -1, "line number to explain, as in the parse errors and the source of the results")
+	flags.StringVar(&event, "event", "", "event number to explain, from the event header up to the next event")
+	flags.BoolVar(&layout, "layout", false, "the file is the text output of pdftotext -layout instead of a pdf")
+	flags.BoolVar(&positions, "positions", false, "parse the word positions (.words.jsonl) instead of the text")
+	flags.BoolVar(&jsonOutput, "json", false, "print the trace as json")
+	flags.StringVar(&mode, "mode", "", "lenient: keep the results of lines with an error, flagged as uncertain. strict: stop at the first error or warning")
+	flags.StringVar(&format, "format", "", "parse the results with the rules of a format definition (json) instead of the built-in formats")
+	flags.Parse(args)
+
+	if filename == "" || (line == -1 && event == "") {
+		flags.PrintDefaults()
+		os.Exit(0)
+	}
+
+	recorder := &parser.TraceRecorder{}
+	options := parseOptions(mode, format)
+	options.Tracer = recorder
+	// the trace up to a parse error is printed before the error: that is when it's needed most
+	_, parseErr := parseFile(filename, layout, positions, options)
+
+	var lines []*parser.LineTrace
+	if line != -1 {
+		lines = recorder.Line(line)
+	} else {
+		lines = recorder.Event(event)
+	}
+
+	if jsonOutput && len(lines) > 0 {
+		out, err := json.MarshalIndent(lines, "", "  ")
+		if err != nil {
+			log.Fatalf("Error creating json (trace): %s", err)
+		}
+		fmt.Println(string(out))
+	} else if !jsonOutput {
+		for _, lineTrace := range lines {
+			fmt.Println(lineTrace)
+		}
+	}
+
+	if parseErr != nil {
+		log.Fatalf("%s", parseErr)
+	}
+	if len(lines) == 0 && line != -1 {
+		log.Fatalf("line %d not found", line)
+	}
+	if len(lines) == 0 {
+		log.Fatalf("event %s not found", event)
+	}
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -13,8 +15,33 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		explain(os.Args[2:])
+		return
+	}
+
 	var filename string
+	var standards bool
+	var scoring string
+	var positions bool
+	var layout bool
+	var omitSource bool
+	var debug bool
+	var mode string
+	var validate bool
+	var corrections string
+	var format string
 	flag.StringVar(&filename, "filename", "", "parse filename")
+	flag.StringVar(&scoring, "scoring", "", "verify the printed points with a scoring table (dual, championship-6/8/10/16/20/24 or a json file)")
+	flag.StringVar(&corrections, "corrections", "", "apply the manual corrections of a json file to the parsed results")
+	flag.StringVar(&format, "format", "", "parse the results with the rules of a format definition (json) instead of the built-in formats")
+	flag.BoolVar(&validate, "validate", false, "check the consistency of the results (places, times, relay swimmers, ages, splits and with -scoring the points)")
+	flag.BoolVar(&standards, "standards", false, "parse a time standards table instead of meet results")
+	flag.BoolVar(&layout, "layout", false, "the file is the text output of pdftotext -layout instead of a pdf")
+	flag.BoolVar(&positions, "positions", false, "parse the word positions (.words.jsonl) instead of the text, to split the columns by position")
+	flag.BoolVar(&omitSource, "omit-source", false, "don't write the source (page, column and line) of every result")
+	flag.StringVar(&mode, "mode", "", "lenient: keep the results of lines with an error, flagged as uncertain. strict: stop at the first error or warning")
+	flag.BoolVar(&debug, "debug", false, "log the rules, fields and rejections of every line to stderr")
 
 	flag.Parse()
 
@@ -24,10 +51,95 @@ func main() {
 	}
 
 	filenameWithoutSuffix := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	options := parseOptions(mode, format)
+	// the corrections find the lines by the source of the records: the source is left out after the corrections
+	options.OmitSource = omitSource && corrections == ""
+	if debug {
+		options.Tracer = parser.SlogTracer(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	}
+
+	if standards {
+		if err := extractPDF(filename); err != nil {
+			log.Fatalf("%s", err)
+		}
+		writeStandards(filenameWithoutSuffix)
+		return
+	}
+
+	result, err := parseFile(filename, layout, positions, options)
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	if corrections != "" {
+		applyCorrections(&result, corrections)
+		if omitSource {
+			parser.ClearSources(&result)
+		}
+	}
+
+	writeResult(filenameWithoutSuffix, result, scoring, validate)
+}
+
+// parseOptions returns the parser options of the -mode and -format flags
+func parseOptions(mode string, format string) parser.Options {
+	if mode != "" && mode != parser.MODE_LENIENT && mode != parser.MODE_STRICT {
+		log.Fatalf("unknown mode '%s' (expected lenient or strict)", mode)
+	}
+	options := parser.Options{Mode: mode}
+	if format != "" {
+		var err error
+		options.Format, err = parser.LoadFormat(format)
+		if err != nil {
+			log.Fatalf("format %s: %s", format, err)
+		}
+	}
+	return options
+}
+
+// parseFile parses a pdf with the extractor, the text output of pdftotext -layout or Meet Manager html results
+func parseFile(filename string, layout bool, positions bool, options parser.Options) (parser.Result, error) {
+	filenameWithoutSuffix := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+
+	if layout {
+		result, err := parser.ParseLayoutTextWithOptions(filename, options)
+		if err != nil {
+			return result, fmt.Errorf("Error parsing layout text: %v", err)
+		}
+		return result, nil
+	}
+
+	// Meet Manager html results don't need the pdf extractor
+	if ext := strings.ToLower(filepath.Ext(filename)); ext == ".htm" || ext == ".html" {
+		result, err := parser.ParseHTMLWithOptions(filename, options)
+		if err != nil {
+			return result, fmt.Errorf("Error parsing html: %v", err)
+		}
+		return result, nil
+	}
+
+	if err := extractPDF(filename); err != nil {
+		return parser.Result{}, err
+	}
+
+	var result parser.Result
+	var err error
+	if positions {
+		result, err = parser.ParsePDFWordsWithOptions(filenameWithoutSuffix+".words.jsonl", options)
+	} else {
+		result, err = parser.ParsePDFTextWithOptions(filenameWithoutSuffix+".txt", options)
+	}
+	if err != nil {
+		return result, fmt.Errorf("Error extracting text: %v", err)
+	}
+	return result, nil
+}
 
+// extractPDF writes the text (.txt) and the word positions (.words.jsonl) of the pdf with the extractor
+func extractPDF(filename string) error {
+	filenameWithoutSuffix := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
 	if !fileExists("pdf-column-extractor-1.0-SNAPSHOT.jar") {
-		fmt.Printf("pdf-column-extractor-1.0-SNAPSHOT.jar doesn't exist. Build the jar file first and place it in the current directory")
-		os.Exit(1)
+		return fmt.Errorf("pdf-column-extractor-1.0-SNAPSHOT.jar doesn't exist. Build the jar file first and place it in the current directory")
 	}
 
 	cmd := exec.Command(
@@ -39,14 +151,25 @@ func main() {
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		log.Fatalf("Error processing %s: %s\n%s.\n", filename, output, err)
+		return fmt.Errorf("Error processing %s: %s\n%s.\n", filename, output, err)
 	}
+	return nil
+}
 
-	result, err := parser.ParsePDFText(filenameWithoutSuffix + ".txt")
+// applyCorrections applies the corrections file and prints the corrections that don't match anymore
+func applyCorrections(result *parser.Result, filename string) {
+	corrections, err := parser.LoadCorrections(filename)
 	if err != nil {
-		log.Fatalf("Error extracting text: %v", err)
+		log.Fatalf("Error loading corrections: %s", err)
+	}
+	stale := corrections.Apply(result)
+	for _, staleCorrection := range stale {
+		fmt.Printf("Correction %d (%s %s) doesn't match: %s\n", staleCorrection.Index, staleCorrection.Correction.Action, staleCorrection.Correction.Type, staleCorrection.Reason)
 	}
+	fmt.Printf("Corrections applied: %d of %d.\n", len(corrections.Corrections)-len(stale), len(corrections.Corrections))
+}
 
+func writeResult(filenameWithoutSuffix string, result parser.Result, scoring string, validate bool) {
 	// write times
 	if len(result.Times) > 0 {
 		csvBytes, err := parser.MarshalCSV(result.Times)
@@ -89,9 +212,91 @@ func main() {
 		}
 	}
 
+	// write unparsed lines
+	if len(result.UnparsedLines) > 0 {
+		csvBytes, err := parser.MarshalCSV(result.UnparsedLines)
+		if err != nil {
+			log.Fatalf("Error creating csv (unparsed lines): %s", err)
+		}
+
+		err = os.WriteFile(filenameWithoutSuffix+"-unparsed.csv", csvBytes, 0644)
+		if err != nil {
+			log.Fatalf("Error creating csv file (unparsed lines): %s", err)
+		}
+	}
+	if result.Detection != nil {
+		fmt.Printf("Format: %s.\n", result.Detection)
+	}
+	coverage := result.Coverage
+	fmt.Printf("Result lines: %d seen, %d recognized (%.1f%%), %d errored, %d ignored.\n", coverage.Lines.Seen, coverage.Lines.Recognized, coverage.Lines.Percentage(), coverage.Lines.Errored, coverage.Lines.Ignored)
+	for _, eventCoverage := range coverage.Events {
+		if eventCoverage.Lines.Seen != eventCoverage.Lines.Recognized {
+			fmt.Printf("  Event %s: %d errored, %d ignored\n", eventCoverage.Event.Round, eventCoverage.Lines.Errored, eventCoverage.Lines.Ignored)
+		}
+	}
+
+	// verify points
+	var table *parser.ScoringTable
+	if scoring != "" {
+		var err error
+		table, err = parser.LoadScoringTable(scoring)
+		if err != nil {
+			log.Fatalf("Error loading scoring table: %s", err)
+		}
+		mismatches := table.VerifyPoints(result)
+		if len(mismatches) > 0 {
+			csvBytes, err := parser.MarshalCSV(mismatches)
+			if err != nil {
+				log.Fatalf("Error creating csv (points): %s", err)
+			}
+
+			err = os.WriteFile(filenameWithoutSuffix+"-points.csv", csvBytes, 0644)
+			if err != nil {
+				log.Fatalf("Error creating csv file (points): %s", err)
+			}
+		}
+		fmt.Printf("Points verified with %s: %d mismatches.\n", table.Name, len(mismatches))
+	}
+
+	// validate the results
+	if validate {
+		issues := parser.Validate(result, parser.ValidationOptions{Scoring: table})
+		if len(issues) > 0 {
+			csvBytes, err := parser.MarshalCSV(issues)
+			if err != nil {
+				log.Fatalf("Error creating csv (issues): %s", err)
+			}
+
+			err = os.WriteFile(filenameWithoutSuffix+"-issues.csv", csvBytes, 0644)
+			if err != nil {
+				log.Fatalf("Error creating csv file (issues): %s", err)
+			}
+		}
+		fmt.Printf("Results validated: %d issues.\n", len(issues))
+	}
+
 	fmt.Println("CSV written.")
 }
 
+func writeStandards(filenameWithoutSuffix string) {
+	standards, err := parser.ParseStandardsText(filenameWithoutSuffix + ".txt")
+	if err != nil {
+		log.Fatalf("Error extracting standards: %v", err)
+	}
+	for _, parseError := range standards.ParseErrors {
+		fmt.Printf("Line %d: %s (%s)\n", parseError.LineNumber, parseError.ErrorMessage, parseError.Line)
+	}
+	out, err := json.MarshalIndent(standards, "", "  ")
+	if err != nil {
+		log.Fatalf("Error creating json (standards): %s", err)
+	}
+	err = os.WriteFile(filenameWithoutSuffix+"-standards.json", out, 0644)
+	if err != nil {
+		log.Fatalf("Error creating json file (standards): %s", err)
+	}
+	fmt.Printf("Standards written (%d times).\n", len(standards.Standards))
+}
+
 func fileExists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil || !os.IsNotExist(err)
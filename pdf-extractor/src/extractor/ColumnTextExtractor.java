@@ -4,6 +4,7 @@ import java.io.File;
 import java.io.IOException;
 import java.nio.file.Files;
 import java.nio.file.Paths;
+import java.util.ArrayList;
 import java.util.List;
 
 import org.apache.pdfbox.pdmodel.PDDocument;
@@ -60,6 +61,8 @@ public class ColumnTextExtractor {
             }
 
             StringBuilder extracted = new StringBuilder();
+            // x positions that separate the columns, for the word positions
+            List<Float> columnEdges = new ArrayList<>();
 
             if (isTwoColumn) {
                 if(!fileType.equals("")) {
@@ -74,9 +77,12 @@ public class ColumnTextExtractor {
 
                     float cutoff = width / 2;
 
-                    if(verticalLines.size() == 1) {
+                    if(verticalLines != null && verticalLines.size() == 1) {
                         cutoff = verticalLines.get(0);
                     }
+                    if(columnEdges.isEmpty()) {
+                        columnEdges.add(cutoff);
+                    }
 
                     PDFTextStripperByArea areaStripper = new PDFTextStripperByArea();
                     areaStripper.setSortByPosition(true);
@@ -94,6 +100,7 @@ public class ColumnTextExtractor {
                 }
             } else if (isThreeColumn && verticalLines != null) {
                 extracted.append("FileType: Three column filetype\n");
+                columnEdges.addAll(verticalLines.subList(0, verticalLines.size() - 1));
                 // --- Step 2a: Three-column extraction ---
                 for (PDPage page : document.getPages()) {
                     int height = (int)page.getMediaBox().getHeight();
@@ -132,5 +139,11 @@ public class ColumnTextExtractor {
             // --- Step 3: Save output ---
             Files.write(Paths.get(outputFile), extracted.toString().getBytes());
             System.out.println("Extraction complete. Output written to " + outputFile);
+
+            // --- Step 4: Save the words with their positions next to the text ---
+            String wordsFile = outputFile.endsWith(".txt") ? outputFile.substring(0, outputFile.length() - 4) + ".words.jsonl" : outputFile + ".words.jsonl";
+            WordExtractor wordExtractor = new WordExtractor(columnEdges);
+            Files.write(Paths.get(wordsFile), wordExtractor.getWords(document, fileType).getBytes());
+            System.out.println("Word positions written to " + wordsFile);
     }
 }
@@ -0,0 +1,101 @@
+package extractor;
+
+import java.io.IOException;
+import java.util.List;
+
+import org.apache.pdfbox.pdmodel.PDDocument;
+import org.apache.pdfbox.text.PDFTextStripper;
+import org.apache.pdfbox.text.TextPosition;
+
+/**
+ * Extracts every word with its page, column and position, as JSON-lines:
+ * {"page":1,"column":0,"x":36.0,"y":90.5,"width":22.1,"height":7.0,"text":"Lastname,"}
+ */
+public class WordExtractor extends PDFTextStripper {
+    // x positions that separate the columns of a page
+    private final List<Float> columnEdges;
+    private final StringBuilder words = new StringBuilder();
+
+    private final StringBuilder word = new StringBuilder();
+    private TextPosition first;
+    private TextPosition last;
+
+    public WordExtractor(List<Float> columnEdges) throws IOException {
+        super();
+        this.columnEdges = columnEdges;
+        setSortByPosition(true);
+    }
+
+    public String getWords(PDDocument document, String fileType) throws IOException {
+        words.setLength(0);
+        if (!fileType.equals("")) {
+            words.append("{\"fileType\":\"").append(escape(fileType)).append("\"}\n");
+        }
+        getText(document);
+        return words.toString();
+    }
+
+    @Override
+    protected void writeString(String text, List<TextPosition> textPositions) throws IOException {
+        for (TextPosition position : textPositions) {
+            String unicode = position.getUnicode();
+            // a gap without a space character also ends a word
+            boolean gap = last != null && position.getXDirAdj() - (last.getXDirAdj() + last.getWidthDirAdj()) > position.getWidthOfSpace() * 0.5f;
+            if (unicode.isBlank() || gap) {
+                writeWord();
+            }
+            if (!unicode.isBlank()) {
+                if (first == null) {
+                    first = position;
+                }
+                word.append(unicode);
+                last = position;
+            }
+        }
+        writeWord();
+    }
+
+    private void writeWord() {
+        if (word.length() == 0) {
+            return;
+        }
+        float x = first.getXDirAdj();
+        words.append("{\"page\":").append(getCurrentPageNo())
+            .append(",\"column\":").append(column(x))
+            .append(",\"x\":").append(x)
+            .append(",\"y\":").append(first.getYDirAdj())
+            .append(",\"width\":").append(last.getXDirAdj() + last.getWidthDirAdj() - x)
+            .append(",\"height\":").append(first.getHeightDir())
+            .append(",\"text\":\"").append(escape(word.toString())).append("\"}\n");
+        word.setLength(0);
+        first = null;
+        last = null;
+    }
+
+    private int column(float x) {
+        int column = 0;
+        if (columnEdges == null) {
+            return column;
+        }
+        for (Float edge : columnEdges) {
+            if (x >= edge) {
+                column++;
+            }
+        }
+        return column;
+    }
+
+    private static String escape(String s) {
+        StringBuilder escaped = new StringBuilder();
+        for (char c : s.toCharArray()) {
+            if (c == '"' || c == '\\') {
+                escaped.append('\\').append(c);
+            } else if (c < 0x20) {
+                escaped.append(String.format("\\u%04x", (int) c));
+            } else {
+                escaped.append(c);
+            }
+        }
+        return escaped.toString();
+    }
+}
@@ -0,0 +1,350 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	TIME_COLUMN_SEED   = "seed"
+	TIME_COLUMN_PRELIM = "prelim"
+	TIME_COLUMN_FINALS = "finals"
+)
+
+const (
+	COLUMN_NAME          = "name"
+	COLUMN_AGE           = "age"
+	COLUMN_YEAR_OF_BIRTH = "yob"
+	COLUMN_GRADE         = "grade"
+	COLUMN_TEAM          = "team"
+	COLUMN_HEAT          = "heat"
+	COLUMN_LANE          = "lane"
+	COLUMN_RELAY         = "relay"
+	COLUMN_POINTS        = "points"
+)
+
+var timeCodeAfterRegex = regexp.MustCompile(`^\s+(?:[YLS]\s+)?(?:DQ|NS|DNF|DFS|SCR)\b`)
+
+// shape of the values in the single word columns
+var columnValueRegex = map[string]*regexp.Regexp{
+	COLUMN_AGE:           regexp.MustCompile(`^\d{1,3}$`),
+	COLUMN_YEAR_OF_BIRTH: regexp.MustCompile(`^(?:19|20)?\d{2}$`),
+	COLUMN_GRADE:         regexp.MustCompile(`^(?:FR|SO|JR|SR|Fr|So|Jr|Sr|\d{1,2})$`),
+	COLUMN_HEAT:          regexp.MustCompile(`^\d{1,3}$`),
+	COLUMN_LANE:          regexp.MustCompile(`^\d{1,2}$`),
+}
+
+// header words and the column they name. Place columns are ignored: the place is always the first value of a row
+var headerColumns = map[string]string{
+	"name":          COLUMN_NAME,
+	"age":           COLUMN_AGE,
+	"ag":            COLUMN_AGE,
+	"yob":           COLUMN_YEAR_OF_BIRTH,
+	"yb":            COLUMN_YEAR_OF_BIRTH,
+	"born":          COLUMN_YEAR_OF_BIRTH,
+	"grade":         COLUMN_GRADE,
+	"gr":            COLUMN_GRADE,
+	"yr":            COLUMN_GRADE,
+	"year":          COLUMN_GRADE,
+	"class":         COLUMN_GRADE,
+	"team":          COLUMN_TEAM,
+	"club":          COLUMN_TEAM,
+	"school":        COLUMN_TEAM,
+	"heat":          COLUMN_HEAT,
+	"lane":          COLUMN_LANE,
+	"relay":         COLUMN_RELAY,
+	"seed":          TIME_COLUMN_SEED,
+	"prelim":        TIME_COLUMN_PRELIM,
+	"prelims":       TIME_COLUMN_PRELIM,
+	"preliminaries": TIME_COLUMN_PRELIM,
+	"final":         TIME_COLUMN_FINALS,
+	"finals":        TIME_COLUMN_FINALS,
+	"time":          TIME_COLUMN_FINALS, // a time column without a round
+	"points":        COLUMN_POINTS,
+	"pts":           COLUMN_POINTS,
+	"pl":            "",
+	"place":         "",
+}
+
+type columnSchema struct {
+	// all columns of the table, in order
+	Columns     []string
+	TimeColumns []string
+}
+
+// parseColumnSchema reads the columns from the header line of a results table.
+// It returns nil for the default Name Age Team Seed / Finals layout.
+// line: Name Age Team Seed Prelims Finals Points
+// line: Name Age Team Prelim Time Finals Time Points
+// line: Name Yr School Seed Time Finals Time Points
+func parseColumnSchema(header string) *columnSchema {
+	schema, _ := parseHeaderColumns(header)
+	if schema.isDefault() {
+		return nil
+	}
+	return schema
+}
+
+// isColumnHeader returns true for header lines other than "Name Age": every word has to be a known column,
+// and the table needs a name, a team and a time column
+// line: Name Yr School Seed Time Finals Time
+// line: Name YOB Club Heat Lane Seed Time
+func isColumnHeader(line string) bool {
+	schema, unknown := parseHeaderColumns(line)
+	if unknown > 0 {
+		return false
+	}
+	return schema.hasColumn(COLUMN_NAME) && schema.hasColumn(COLUMN_TEAM) && len(schema.TimeColumns) > 0
+}
+
+// parseHeaderColumns returns the schema of the header line, and the number of words that aren't a column
+func parseHeaderColumns(header string) (*columnSchema, int) {
+	schema := &columnSchema{}
+	unknown := 0
+	fields := strings.Fields(header)
+	for k := 0; k < len(fields); k++ {
+		word := strings.ToLower(fields[k])
+		if word == "ag" && k+1 < len(fields) && fields[k+1] == "e" {
+			k++ // Name Ag  e
+		}
+		column, ok := headerColumns[word]
+		if !ok {
+			unknown++
+			continue
+		}
+		if column == "" {
+			continue
+		}
+		if isTimeColumn(column) && word != "time" && k+1 < len(fields) && strings.EqualFold(fields[k+1], "time") {
+			k++ // Seed Time, Finals Time
+		}
+		schema.Columns = append(schema.Columns, column)
+		if isTimeColumn(column) {
+			schema.TimeColumns = append(schema.TimeColumns, column)
+		}
+	}
+	return schema, unknown
+}
+
+func isTimeColumn(column string) bool {
+	return column == TIME_COLUMN_SEED || column == TIME_COLUMN_PRELIM || column == TIME_COLUMN_FINALS
+}
+
+// isDefault returns true when the rows can be parsed with the default layout: name, age and team,
+// followed by a seed and finals time. A table with only a finals time isn't the default: a second
+// number that looks like a time is the points (12.50 for a tie), not the finals time.
+func (c *columnSchema) isDefault() bool {
+	return c.hasDefaultIdentity() && len(c.TimeColumns) == 2 && !c.hasTimeColumn(TIME_COLUMN_PRELIM)
+}
+
+// hasDefaultIdentity returns true when the table starts with the name, age and team columns
+func (c *columnSchema) hasDefaultIdentity() bool {
+	if c == nil {
+		return true
+	}
+	identity := c.identityColumns()
+	return len(identity) == 3 && identity[0] == COLUMN_NAME && identity[1] == COLUMN_AGE && identity[2] == COLUMN_TEAM
+}
+
+// identityColumns returns the columns in front of the first time column
+func (c *columnSchema) identityColumns() []string {
+	for k, column := range c.Columns {
+		if isTimeColumn(column) {
+			return c.Columns[:k]
+		}
+	}
+	return c.Columns
+}
+
+func (c *columnSchema) hasColumn(column string) bool {
+	if c == nil {
+		return column == COLUMN_NAME || column == COLUMN_AGE || column == COLUMN_TEAM || c.hasTimeColumn(column)
+	}
+	for _, schemaColumn := range c.Columns {
+		if schemaColumn == column {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *columnSchema) hasTimeColumn(column string) bool {
+	if c == nil {
+		return column == TIME_COLUMN_SEED || column == TIME_COLUMN_FINALS
+	}
+	for _, timeColumn := range c.TimeColumns {
+		if timeColumn == column {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *columnSchema) timeColumnCount() int {
+	if c == nil {
+		return 2
+	}
+	return len(c.TimeColumns)
+}
+
+// splitPrelimTime cuts the prelim time out of a row with seed, prelim and finals times, so the
+// remaining seed and finals times can be parsed like a regular row. When only the seed and
+// prelim times are on the row (no finals swim), prelimOnly is true: the last time is the prelim time.
+// line: 1:00.00 59.00 58.50 20
+// line: 1:00.00 59.00 DQ
+func (c *columnSchema) splitPrelimTime(line string) (string, string, bool) {
+	if c == nil || len(c.TimeColumns) < 3 {
+		return line, "", false
+	}
+	prelimIndex := -1
+	for k, column := range c.TimeColumns {
+		if column == TIME_COLUMN_PRELIM {
+			prelimIndex = k
+		}
+	}
+	matches := timesRegex.FindAllStringIndex(line, -1)
+	if prelimIndex >= len(matches) {
+		return line, "", false
+	}
+	switch {
+	case len(matches) == len(c.TimeColumns):
+	case len(matches) == len(c.TimeColumns)-1:
+		// finals code (DQ, NS, ...) after the prelim time
+		if !timeCodeAfterRegex.MatchString(line[matches[len(matches)-1][1]:]) {
+			return line, "", prelimIndex == len(matches)-1
+		}
+	default:
+		return line, "", false
+	}
+	match := matches[prelimIndex]
+	prelim := line[match[0]:match[1]]
+	start := match[0]
+	if start > 0 && line[start-1] == ' ' {
+		start--
+	}
+	return line[:start] + line[match[1]:], prelim, false
+}
+
+// processLineColumns parses a row of a table that doesn't have the default Name Age Team layout.
+// The columns in front of the times are read in the order of the header, the times are parsed like a regular row.
+// header: Name Yr School Seed Time Finals Time Points
+// line: 1 Lastname, Firstname SR Lynchburg High School 1:00.00 58.50 20
+// header: Name YOB Club Heat Lane Seed Time
+// line: 1 Lastname, Firstname 2010 Lynchburg YMCA 3 4 1:00.00
+func processLineColumns(line string, schema *columnSchema) (*SwimmerTime, error) {
+	swimmer := &SwimmerTime{}
+	var err error
+	line, exhibition := stripExhibitionMarker(line)
+	index1 := strings.Index(line, " ")
+	if index1 == -1 {
+		return swimmer, fmt.Errorf("couldn't determine place")
+	}
+	swimmer.Place, err = parsePlace(line[0:index1])
+	if err != nil {
+		return swimmer, fmt.Errorf("couldn't determine place: %s", err)
+	}
+	swimmer.Place.Exhibition = swimmer.Place.Exhibition || exhibition
+	line = strings.TrimLeft(line[index1+1:], " ")
+
+	identity := schema.identityColumns()
+	teamLast := len(identity) > 0 && identity[len(identity)-1] == COLUMN_TEAM
+	for k, column := range identity {
+		last := k == len(identity)-1
+		if last && teamLast {
+			break // the team name is in front of the times
+		}
+		value := ""
+		if columnValueRegex[column] != nil {
+			value, line = nextWord(line)
+			if !columnValueRegex[column].MatchString(value) {
+				return swimmer, fmt.Errorf("unexpected value for column %s: '%s'", column, value)
+			}
+		} else if last {
+			return swimmer, fmt.Errorf("column %s can't be in front of the times", column)
+		} else {
+			value, line, err = nextTextColumn(line, identity[k+1])
+			if err != nil {
+				return swimmer, fmt.Errorf("couldn't determine %s: %s", column, err)
+			}
+		}
+		setColumn(swimmer, column, value)
+	}
+	if !teamLast {
+		line = " " + line // no team name in front of the times
+	}
+
+	err = processTimeColumns(swimmer, line, schema)
+	if err != nil {
+		return swimmer, err
+	}
+	if swimmer.TeamName == "" && schema.hasColumn(COLUMN_TEAM) {
+		return swimmer, fmt.Errorf("couldn't determine team name")
+	}
+	return swimmer, nil
+}
+
+// nextWord returns the first word of the line, and the line after the word
+func nextWord(line string) (string, string) {
+	index := strings.Index(line, " ")
+	if index == -1 {
+		return line, ""
+	}
+	return line[0:index], strings.TrimLeft(line[index+1:], " ")
+}
+
+// nextTextColumn returns the words up to the value of the next column.
+// When the next column is also text, the columns have to be separated by at least two spaces.
+func nextTextColumn(line string, nextColumn string) (string, string, error) {
+	nextRegex := columnValueRegex[nextColumn]
+	if nextRegex == nil {
+		index := strings.Index(line, "  ")
+		if index == -1 {
+			return "", line, fmt.Errorf("no spacing found in front of column %s", nextColumn)
+		}
+		return line[0:index], strings.TrimLeft(line[index:], " "), nil
+	}
+	value := ""
+	for line != "" {
+		word, rest := nextWord(line)
+		if value != "" && nextRegex.MatchString(word) {
+			return value, line, nil
+		}
+		if value != "" {
+			value += " "
+		}
+		value += word
+		line = rest
+	}
+	return value, line, fmt.Errorf("column %s not found", nextColumn)
+}
+
+func setColumn(swimmer *SwimmerTime, column string, value string) {
+	switch column {
+	case COLUMN_NAME:
+		swimmer.Name = value
+	case COLUMN_AGE:
+		swimmer.Age = value
+	case COLUMN_YEAR_OF_BIRTH:
+		swimmer.YearOfBirth = value
+	case COLUMN_GRADE:
+		swimmer.Grade = value
+	case COLUMN_TEAM:
+		setTeamName(swimmer, value)
+	case COLUMN_HEAT:
+		swimmer.Heat = value
+	case COLUMN_LANE:
+		swimmer.Lane = value
+	}
+}
+
+// setTeamName splits the LSC from the team name
+// team: Mansfield Aquatic Club-NT
+func setTeamName(swimmer *SwimmerTime, teamName string) {
+	if index := strings.Index(teamName, "-"); index != -1 {
+		swimmer.TeamLSC = teamName[index+1:]
+		swimmer.TeamName = teamName[0:index]
+	} else {
+		swimmer.TeamName = teamName
+	}
+}
@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseColumnSchema(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected *columnSchema
+	}{
+		{"Name Age Team Seed Time Finals Time Points", nil},
+		{"Name Ag  e Team Seed Time Finals Time", nil},
+		{"Name Age Team Seed Prelims Finals Points", &columnSchema{
+			Columns:     []string{COLUMN_NAME, COLUMN_AGE, COLUMN_TEAM, TIME_COLUMN_SEED, TIME_COLUMN_PRELIM, TIME_COLUMN_FINALS, COLUMN_POINTS},
+			TimeColumns: []string{TIME_COLUMN_SEED, TIME_COLUMN_PRELIM, TIME_COLUMN_FINALS},
+		}},
+		{"Name Age Team Prelim Time Finals Time Points", &columnSchema{
+			Columns:     []string{COLUMN_NAME, COLUMN_AGE, COLUMN_TEAM, TIME_COLUMN_PRELIM, TIME_COLUMN_FINALS, COLUMN_POINTS},
+			TimeColumns: []string{TIME_COLUMN_PRELIM, TIME_COLUMN_FINALS},
+		}},
+		{"Name Age Team Seed Time Prelim Time", &columnSchema{
+			Columns:     []string{COLUMN_NAME, COLUMN_AGE, COLUMN_TEAM, TIME_COLUMN_SEED, TIME_COLUMN_PRELIM},
+			TimeColumns: []string{TIME_COLUMN_SEED, TIME_COLUMN_PRELIM},
+		}},
+		{"Name Yr School Seed Time Finals Time Points", &columnSchema{
+			Columns:     []string{COLUMN_NAME, COLUMN_GRADE, COLUMN_TEAM, TIME_COLUMN_SEED, TIME_COLUMN_FINALS, COLUMN_POINTS},
+			TimeColumns: []string{TIME_COLUMN_SEED, TIME_COLUMN_FINALS},
+		}},
+		{"Pl Name YOB Club Heat Lane Time", &columnSchema{
+			Columns:     []string{COLUMN_NAME, COLUMN_YEAR_OF_BIRTH, COLUMN_TEAM, COLUMN_HEAT, COLUMN_LANE, TIME_COLUMN_FINALS},
+			TimeColumns: []string{TIME_COLUMN_FINALS},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, parseColumnSchema(tt.header)); diff != "" {
+				t.Fatalf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProcessLineType1PrelimsFinals(t *testing.T) {
+	seedPrelimsFinals := parseColumnSchema("Name Age Team Seed Prelims Finals Points")
+	prelimsFinals := parseColumnSchema("Name Age Team Prelim Time Finals Time Points")
+	tests := []struct {
+		line     string
+		schema   *columnSchema
+		expected SwimmerTime
+	}{
+		{
+			"1 Lastname, Firstname  14 Lynchburg YMCA 1:00.00 59.00 58.50 20",
+			seedPrelimsFinals,
+			SwimmerTime{Place: Place{Value: 1}, Name: "Lastname, Firstname", Age: "14", TeamName: "Lynchburg YMCA", SeedTime: "1:00.00", PrelimTime: "59.00", Time: "58.50", Points: 20, PointsPrinted: true},
+		},
+		{
+			"1 Lastname, Firstname  14 Lynchburg YMCA 1:00.00 Y 59.00 58.50 12.50",
+			seedPrelimsFinals,
+			SwimmerTime{Place: Place{Value: 1, Tie: false}, Name: "Lastname, Firstname", Age: "14", TeamName: "Lynchburg YMCA", SeedTime: "1:00.00", SeedTimeTag: "Y", PrelimTime: "59.00", Time: "58.50", Points: 12.5, PointsPrinted: true},
+		},
+		{
+			"--- Lastname, Firstname  14 Lynchburg YMCA 1:00.00 59.00 DQ",
+			seedPrelimsFinals,
+			SwimmerTime{Place: Place{Unranked: true}, Name: "Lastname, Firstname", Age: "14", TeamName: "Lynchburg YMCA", SeedTime: "1:00.00", PrelimTime: "59.00", Time: "DQ"},
+		},
+		{
+			"17 Lastname, Firstname  14 Lynchburg YMCA 1:00.00 59.00",
+			seedPrelimsFinals,
+			SwimmerTime{Place: Place{Value: 17}, Name: "Lastname, Firstname", Age: "14", TeamName: "Lynchburg YMCA", SeedTime: "1:00.00", PrelimTime: "59.00", Time: "59.00"},
+		},
+		{
+			"2 Lastname, Firstname  14 Lynchburg YMCA 59.00 58.50 17",
+			prelimsFinals,
+			SwimmerTime{Place: Place{Value: 2}, Name: "Lastname, Firstname", Age: "14", TeamName: "Lynchburg YMCA", PrelimTime: "59.00", Time: "58.50", Points: 17, PointsPrinted: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			parsed, err := processLineType1(tt.line, tt.schema)
+			if err != nil {
+				t.Fatalf("error: %s. SwimmerTime: %+v", err, parsed)
+			}
+			if diff := cmp.Diff(tt.expected, *parsed); diff != "" {
+				t.Fatalf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsColumnHeader(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected bool
+	}{
+		{"Name Yr School Seed Time Finals Time Points", true},
+		{"Name Team Age Seed Time Finals Time", true},
+		{"Pl Name YOB Club Heat Lane Time", true},
+		{"Name Age Team Seed Time Finals Time", true},
+		{"Team Relay Seed Time Finals Time Points", false},
+		{"Name Yr School Seed Time Finals Time Points Results", false},
+		{"Name Yr School", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if got := isColumnHeader(tt.line); got != tt.expected {
+				t.Fatalf("isColumnHeader(%q) = %v; want %v", tt.line, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProcessLineColumns(t *testing.T) {
+	tests := []struct {
+		header   string
+		line     string
+		expected SwimmerTime
+	}{
+		{
+			"Name Yr School Seed Time Finals Time Points",
+			"1 Lastname, Firstname SR Lynchburg High School 1:00.00 58.50 20",
+			SwimmerTime{Place: Place{Value: 1}, Name: "Lastname, Firstname", Grade: "SR", TeamName: "Lynchburg High School", SeedTime: "1:00.00", Time: "58.50", Points: 20, PointsPrinted: true},
+		},
+		{
+			"Name Yr School Seed Time Finals Time Points",
+			"--- Lastname, Firstname Middle 10 Lynchburg High School NT DQ",
+			SwimmerTime{Place: Place{Unranked: true}, Name: "Lastname, Firstname Middle", Grade: "10", TeamName: "Lynchburg High School", SeedTime: "NT", Time: "DQ"},
+		},
+		{
+			"Name Team Age Seed Time Finals Time",
+			"2 Lastname, Firstname  Nitro Swimming-ST  12 1:10.00 1:08.81",
+			SwimmerTime{Place: Place{Value: 2}, Name: "Lastname, Firstname", Age: "12", TeamName: "Nitro Swimming", TeamLSC: "ST", SeedTime: "1:10.00", Time: "1:08.81"},
+		},
+		{
+			"Pl Name YOB Club Heat Lane Time",
+			"3 Lastname, Firstname 2010 Swim Club 2000 2 5 1:02.13",
+			SwimmerTime{Place: Place{Value: 3}, Name: "Lastname, Firstname", YearOfBirth: "2010", TeamName: "Swim Club 2000", Heat: "2", Lane: "5", Time: "1:02.13"},
+		},
+		{
+			"Name Age Team Seed Time Finals Time Points",
+			"1 Lastname, Firstname  14 Lynchburg YMCA 2:14.96 2:16.72 AG 9",
+			SwimmerTime{Place: Place{Value: 1}, Name: "Lastname, Firstname", Age: "14", TeamName: "Lynchburg YMCA", SeedTime: "2:14.96", Time: "2:16.72", QualifyingStandards: "AG", Points: 9, PointsPrinted: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			parsed, err := processLine(tt.line, FILETYPE_TYPE1, parseColumnSchema(tt.header))
+			if err != nil {
+				t.Fatalf("error: %s. SwimmerTime: %+v", err, parsed)
+			}
+			if diff := cmp.Diff(tt.expected, *parsed); diff != "" {
+				t.Fatalf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+const (
+	CORRECTION_REPLACE = "replace"
+	CORRECTION_DELETE  = "delete"
+	CORRECTION_ADD     = "add"
+)
+
+const (
+	CORRECTION_TYPE_TIME  = "time"
+	CORRECTION_TYPE_RELAY = "relay"
+	CORRECTION_TYPE_EVENT = "event"
+)
+
+// Corrections are manual fixes of the parsed records, kept in a json file next to the document so they survive a re-parse:
+//
+//	{"corrections": [
+//	  {"action": "replace", "type": "time", "lineNumber": 123, "lineHash": "9f86d081884c7d65", "swimmerTime": {...}},
+//	  {"action": "delete", "type": "relay", "record": {"event": "12", "team": "Lynchburg YMCA", "relay": "A"}},
+//	  {"action": "add", "type": "time", "swimmerTime": {"event": {"round": "5"}, "place": {"value": 3}, ...}}
+//	]}
+type Corrections struct {
+	Corrections []*Correction `json:"corrections"`
+}
+
+// Correction replaces, deletes or adds a swimmer time, relay time or event. The record is found by the line it was
+// parsed from (the line number and the hash of the line) or by its identity. An added record with a line is
+// added for that line, which is usually a line with a parse error.
+type Correction struct {
+	Action     string     `json:"action"`
+	Type       string     `json:"type"`
+	LineNumber *int       `json:"lineNumber,omitempty"`
+	LineHash   string     `json:"lineHash,omitempty"`
+	Record     *RecordKey `json:"record,omitempty"`
+	// the corrected or added record. The event of a record is the parsed event with the same event number;
+	// a replacement without an event or source keeps the event and source of the record it replaces
+	SwimmerTime *SwimmerTime `json:"swimmerTime,omitempty"`
+	RelayTime   *RelayTime   `json:"relayTime,omitempty"`
+	Event       *Event       `json:"event,omitempty"`
+	Comment     string       `json:"comment,omitempty"`
+}
+
+// RecordKey identifies a record without its line: the event number and round, with the name and team of a
+// swimmer time or the team and relay letter of a relay time
+type RecordKey struct {
+	Event      string `json:"event"`
+	Round      string `json:"round,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Team       string `json:"team,omitempty"`
+	RelayEntry string `json:"relay,omitempty"`
+}
+
+// StaleCorrection is a correction that doesn't match the parsed records anymore, because the document
+// or the parser changed
+type StaleCorrection struct {
+	Index      int         `json:"index"`
+	Correction *Correction `json:"correction"`
+	Reason     string      `json:"reason"`
+}
+
+// LineHash returns the hash of a line of the document, as used in the corrections
+func LineHash(line string) string {
+	hash := sha256.Sum256([]byte(line))
+	return hex.EncodeToString(hash[:8])
+}
+
+func LoadCorrections(filePath string) (*Corrections, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	corrections := &Corrections{}
+	if err := json.Unmarshal(data, corrections); err != nil {
+		return nil, fmt.Errorf("couldn't parse corrections: %s", err)
+	}
+	for k, correction := range corrections.Corrections {
+		if err := correction.validate(); err != nil {
+			return nil, fmt.Errorf("correction %d: %s", k, err)
+		}
+	}
+	return corrections, nil
+}
+
+func (c *Correction) validate() error {
+	if c.Action != CORRECTION_REPLACE && c.Action != CORRECTION_DELETE && c.Action != CORRECTION_ADD {
+		return fmt.Errorf("unknown action '%s' (expected replace, delete or add)", c.Action)
+	}
+	if c.Type != CORRECTION_TYPE_TIME && c.Type != CORRECTION_TYPE_RELAY && c.Type != CORRECTION_TYPE_EVENT {
+		return fmt.Errorf("unknown type '%s' (expected time, relay or event)", c.Type)
+	}
+	if c.LineNumber != nil && c.LineHash == "" {
+		return fmt.Errorf("a line number needs the hash of the line")
+	}
+	if c.Action != CORRECTION_ADD && c.LineNumber == nil && c.Record == nil {
+		return fmt.Errorf("%s needs a line number or a record", c.Action)
+	}
+	if c.Action != CORRECTION_DELETE && c.record() == nil {
+		return fmt.Errorf("%s needs a %s record", c.Action, c.Type)
+	}
+	return nil
+}
+
+// record returns the corrected record of the type of the correction, or nil
+func (c *Correction) record() interface{} {
+	switch {
+	case c.Type == CORRECTION_TYPE_TIME && c.SwimmerTime != nil:
+		return c.SwimmerTime
+	case c.Type == CORRECTION_TYPE_RELAY && c.RelayTime != nil:
+		return c.RelayTime
+	case c.Type == CORRECTION_TYPE_EVENT && c.Event != nil:
+		return c.Event
+	}
+	return nil
+}
+
+// Apply applies the corrections to the result, in order, and returns the corrections that didn't match.
+// Records are found by line through their source: the result can't be parsed with OmitSource, use
+// ClearSources after the corrections instead.
+// Deleting an event also deletes its swimmer and relay times.
+func (c *Corrections) Apply(result *Result) []*StaleCorrection {
+	stale := []*StaleCorrection{}
+	for k, correction := range c.Corrections {
+		if err := applyCorrection(result, correction); err != nil {
+			stale = append(stale, &StaleCorrection{Index: k, Correction: correction, Reason: err.Error()})
+		}
+	}
+	return stale
+}
+
+// ClearSources removes the source of the events, times and relay times, like parsing with OmitSource
+func ClearSources(result *Result) {
+	for _, event := range result.Events {
+		event.Source = nil
+	}
+	for _, swimmerTime := range result.Times {
+		swimmerTime.Source = nil
+	}
+	for _, relayTime := range result.RelayTimes {
+		relayTime.Source = nil
+	}
+}
+
+func applyCorrection(result *Result, correction *Correction) error {
+	var source *Source
+	if correction.LineNumber != nil {
+		source = findLine(result, *correction.LineNumber, correction.LineHash)
+		if source == nil {
+			return lineNotFound(result, *correction.LineNumber)
+		}
+	}
+
+	if correction.Action == CORRECTION_ADD {
+		if source != nil {
+			for _, recordSource := range recordSources(result, correction.Type) {
+				if recordSource == source {
+					return fmt.Errorf("line %d is parsed now, the %s record doesn't need to be added", source.LineNumber, correction.Type)
+				}
+			}
+		}
+		return addRecord(result, correction, source)
+	}
+
+	matches := []int{}
+	for k, recordSource := range recordSources(result, correction.Type) {
+		if source != nil && (recordSource == nil || recordSource.LineNumber != source.LineNumber || recordSource.Line != source.Line) {
+			continue
+		}
+		if correction.Record != nil && !correction.Record.matches(result, correction.Type, k) {
+			continue
+		}
+		matches = append(matches, k)
+	}
+	switch {
+	case len(matches) == 0:
+		return fmt.Errorf("no %s record matches the correction", correction.Type)
+	case len(matches) > 1:
+		return fmt.Errorf("%d %s records match the correction", len(matches), correction.Type)
+	}
+	index := matches[0]
+
+	if correction.Action == CORRECTION_DELETE {
+		switch correction.Type {
+		case CORRECTION_TYPE_TIME:
+			result.Times = slices.Delete(result.Times, index, index+1)
+		case CORRECTION_TYPE_RELAY:
+			result.RelayTimes = slices.Delete(result.RelayTimes, index, index+1)
+		case CORRECTION_TYPE_EVENT:
+			event := result.Events[index]
+			result.Events = slices.Delete(result.Events, index, index+1)
+			result.Times = slices.DeleteFunc(result.Times, func(swimmerTime *SwimmerTime) bool { return swimmerTime.Event == event })
+			result.RelayTimes = slices.DeleteFunc(result.RelayTimes, func(relayTime *RelayTime) bool { return relayTime.Event == event })
+		}
+		return nil
+	}
+
+	// the record is replaced in place: the times of a replaced event keep pointing to the event
+	switch correction.Type {
+	case CORRECTION_TYPE_TIME:
+		swimmerTime := *correction.SwimmerTime
+		original := result.Times[index]
+		if err := correctionEvent(result, &swimmerTime.Event, original.Event); err != nil {
+			return err
+		}
+		if swimmerTime.Source == nil {
+			swimmerTime.Source = original.Source
+		}
+		*original = swimmerTime
+	case CORRECTION_TYPE_RELAY:
+		relayTime := *correction.RelayTime
+		original := result.RelayTimes[index]
+		if err := correctionEvent(result, &relayTime.Event, original.Event); err != nil {
+			return err
+		}
+		if relayTime.Source == nil {
+			relayTime.Source = original.Source
+		}
+		*original = relayTime
+	case CORRECTION_TYPE_EVENT:
+		event := *correction.Event
+		original := result.Events[index]
+		if event.Source == nil {
+			event.Source = original.Source
+		}
+		if event.QualifyingTimes == nil {
+			event.QualifyingTimes = map[string]string{}
+		}
+		*original = event
+	}
+	return nil
+}
+
+// addRecord adds the record of the correction after the records of the lines in front of it
+func addRecord(result *Result, correction *Correction, source *Source) error {
+	switch correction.Type {
+	case CORRECTION_TYPE_TIME:
+		swimmerTime := *correction.SwimmerTime
+		if err := correctionEvent(result, &swimmerTime.Event, nil); err != nil {
+			return err
+		}
+		if source != nil {
+			swimmerTime.Source = source
+		}
+		index := insertIndex(recordSources(result, correction.Type), swimmerTime.Source)
+		result.Times = slices.Insert(result.Times, index, &swimmerTime)
+	case CORRECTION_TYPE_RELAY:
+		relayTime := *correction.RelayTime
+		if err := correctionEvent(result, &relayTime.Event, nil); err != nil {
+			return err
+		}
+		if relayTime.Swimmers == nil {
+			relayTime.Swimmers = []*RelaySwimmer{}
+		}
+		if source != nil {
+			relayTime.Source = source
+		}
+		index := insertIndex(recordSources(result, correction.Type), relayTime.Source)
+		result.RelayTimes = slices.Insert(result.RelayTimes, index, &relayTime)
+	case CORRECTION_TYPE_EVENT:
+		event := *correction.Event
+		if event.QualifyingTimes == nil {
+			event.QualifyingTimes = map[string]string{}
+		}
+		if source != nil {
+			event.Source = source
+		}
+		index := insertIndex(recordSources(result, correction.Type), event.Source)
+		result.Events = slices.Insert(result.Events, index, &event)
+	}
+	return nil
+}
+
+// correctionEvent resolves the event of a corrected record to the parsed event with the same event number.
+// Without an event number, the record keeps the event of the original record.
+func correctionEvent(result *Result, event **Event, original *Event) error {
+	if *event == nil || (*event).Round == "" {
+		*event = original
+		return nil
+	}
+	for _, parsedEvent := range result.Events {
+		if parsedEvent.Round == (*event).Round {
+			*event = parsedEvent
+			return nil
+		}
+	}
+	return fmt.Errorf("event %s not found", (*event).Round)
+}
+
+// insertIndex returns the index of the first record parsed from a line after the source, or the end of the records
+func insertIndex(sources []*Source, source *Source) int {
+	if source == nil {
+		return len(sources)
+	}
+	for k, recordSource := range sources {
+		if recordSource != nil && recordSource.LineNumber > source.LineNumber {
+			return k
+		}
+	}
+	return len(sources)
+}
+
+// recordSources returns the source of every record of a type, in the order of the records
+func recordSources(result *Result, recordType string) []*Source {
+	sources := []*Source{}
+	switch recordType {
+	case CORRECTION_TYPE_TIME:
+		for _, swimmerTime := range result.Times {
+			sources = append(sources, swimmerTime.Source)
+		}
+	case CORRECTION_TYPE_RELAY:
+		for _, relayTime := range result.RelayTimes {
+			sources = append(sources, relayTime.Source)
+		}
+	case CORRECTION_TYPE_EVENT:
+		for _, event := range result.Events {
+			sources = append(sources, event.Source)
+		}
+	}
+	return sources
+}
+
+// documentLines returns the lines the result knows about: the source of the records, the lines with
+// a parse error and the unparsed lines
+func documentLines(result *Result) []*Source {
+	lines := []*Source{}
+	for _, recordType := range []string{CORRECTION_TYPE_EVENT, CORRECTION_TYPE_TIME, CORRECTION_TYPE_RELAY} {
+		for _, source := range recordSources(result, recordType) {
+			if source != nil {
+				lines = append(lines, source)
+			}
+		}
+	}
+	for _, parseError := range result.ParseErrors {
+		lines = append(lines, &Source{LineNumber: parseError.LineNumber, Line: parseError.Line})
+	}
+	for _, unparsedLine := range result.UnparsedLines {
+		lines = append(lines, &Source{LineNumber: unparsedLine.LineNumber, Line: unparsedLine.Line})
+	}
+	return lines
+}
+
+// findLine returns the line with the line number and hash. A line number of pdftotext -layout output
+// has a line in every column: the hash picks the line.
+func findLine(result *Result, lineNumber int, lineHash string) *Source {
+	for _, line := range documentLines(result) {
+		if line.LineNumber == lineNumber && LineHash(line.Line) == lineHash {
+			return line
+		}
+	}
+	return nil
+}
+
+// lineNotFound explains why a line of a correction doesn't match: the line changed, or isn't known anymore
+func lineNotFound(result *Result, lineNumber int) error {
+	for _, line := range documentLines(result) {
+		if line.LineNumber == lineNumber {
+			return fmt.Errorf("line %d changed (hash %s): %s", lineNumber, LineHash(line.Line), line.Line)
+		}
+	}
+	return fmt.Errorf("line %d not found", lineNumber)
+}
+
+func (k *RecordKey) matches(result *Result, recordType string, index int) bool {
+	switch recordType {
+	case CORRECTION_TYPE_TIME:
+		swimmerTime := result.Times[index]
+		return k.matchesEvent(swimmerTime.Event) && k.Round == swimmerTime.Round &&
+			k.Name == swimmerTime.Name && (k.Team == "" || k.Team == swimmerTime.TeamName)
+	case CORRECTION_TYPE_RELAY:
+		relayTime := result.RelayTimes[index]
+		return k.matchesEvent(relayTime.Event) && k.Round == relayTime.Round &&
+			(k.Team == relayTime.TeamName || k.Team == relayTime.TeamNameShort) && k.RelayEntry == relayTime.RelayEntry
+	case CORRECTION_TYPE_EVENT:
+		return k.matchesEvent(result.Events[index])
+	}
+	return false
+}
+
+func (k *RecordKey) matchesEvent(event *Event) bool {
+	return event != nil && event.Round == k.Event
+}
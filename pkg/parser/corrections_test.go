@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCorrectionsApply(t *testing.T) {
+	input := "Event 1  Girls 10 & Under 50 Yard Freestyle\n" +
+		"Name Age Team Seed Time Finals Time Points\n" +
+		"1 Lastname, Firstname  10 Lynchburg YMCA 33.10 32.54 9\n" +
+		"2 Lastname, Second 34.00 33.80 33.70\n" +
+		"3 Lastname, Third  9 Heritage Swim 35.00 34.80 6\n" +
+		"Event 2  Girls 10 & Under 200 Yard Freestyle Relay\n" +
+		"Team  Relay Seed Time Finals Time Points\n" +
+		"1 Lynchburg YMCA     A 2:30.00 2:25.10 18\n" +
+		"2 Heritage Swim     A 2:40.00 2:35.10 14\n"
+	result, err := parsePDFText(bytes.NewBufferString(input), Options{})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(result.Times) != 2 || len(result.RelayTimes) != 2 {
+		t.Fatalf("got %d times and %d relay times, expected 2 and 2", len(result.Times), len(result.RelayTimes))
+	}
+
+	brokenLine := 3
+	relayLine := 8
+	changedLine := 4
+	corrections := &Corrections{Corrections: []*Correction{
+		// the line with the parse error
+		{Action: CORRECTION_ADD, Type: CORRECTION_TYPE_TIME, LineNumber: &brokenLine, LineHash: LineHash("2 Lastname, Second 34.00 33.80 33.70"),
+			SwimmerTime: &SwimmerTime{Event: &Event{Round: "1"}, Place: Place{Value: 2}, Name: "Lastname, Second", Age: "10", TeamName: "Heritage Swim", SeedTime: "34.00", Time: "33.80", Points: 7, PointsPrinted: true}},
+		{Action: CORRECTION_REPLACE, Type: CORRECTION_TYPE_TIME, Record: &RecordKey{Event: "1", Name: "Lastname, Third"},
+			SwimmerTime: &SwimmerTime{Place: Place{Value: 3}, Name: "Lastname, Third", Age: "9", TeamName: "Heritage Swim", SeedTime: "35.00", Time: "34.80", Points: 6, PointsPrinted: true}},
+		{Action: CORRECTION_DELETE, Type: CORRECTION_TYPE_RELAY, LineNumber: &relayLine, LineHash: LineHash("2 Heritage Swim     A 2:40.00 2:35.10 14")},
+		// stale: the line changed, the swimmer isn't in the results, the event doesn't exist
+		{Action: CORRECTION_DELETE, Type: CORRECTION_TYPE_TIME, LineNumber: &changedLine, LineHash: LineHash("3 Lastname, Third  9 Heritage Swim 35.00 34.90 6")},
+		{Action: CORRECTION_DELETE, Type: CORRECTION_TYPE_TIME, Record: &RecordKey{Event: "1", Name: "Lastname, Fourth"}},
+		{Action: CORRECTION_ADD, Type: CORRECTION_TYPE_TIME, SwimmerTime: &SwimmerTime{Event: &Event{Round: "3"}, Name: "Lastname, Fifth"}},
+	}}
+	stale := corrections.Apply(&result)
+
+	names := []string{}
+	for _, swimmerTime := range result.Times {
+		names = append(names, swimmerTime.Name)
+		if swimmerTime.Event != result.Events[0] || swimmerTime.Source == nil {
+			t.Fatalf("swimmer time of %s without the event or source", swimmerTime.Name)
+		}
+	}
+	if diff := cmp.Diff([]string{"Lastname, Firstname", "Lastname, Second", "Lastname, Third"}, names); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+	if result.Times[1].Source.LineNumber != brokenLine || result.Times[2].Source.LineNumber != 4 {
+		t.Fatalf("got the sources %s and %s, expected line 3 and 4", result.Times[1].Source, result.Times[2].Source)
+	}
+	if len(result.RelayTimes) != 1 || result.RelayTimes[0].TeamName != "Lynchburg YMCA" {
+		t.Fatalf("got %d relay times, expected the Lynchburg YMCA relay", len(result.RelayTimes))
+	}
+
+	reasons := []string{}
+	for _, staleCorrection := range stale {
+		reasons = append(reasons, staleCorrection.Reason)
+	}
+	expected := []string{
+		"line 4 changed (hash " + LineHash("3 Lastname, Third  9 Heritage Swim 35.00 34.80 6") + "): 3 Lastname, Third  9 Heritage Swim 35.00 34.80 6",
+		"no time record matches the correction",
+		"event 3 not found",
+	}
+	if diff := cmp.Diff(expected, reasons); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+
+	// once the parser reads the line, the added record is stale
+	stale = (&Corrections{Corrections: corrections.Corrections[:1]}).Apply(&result)
+	if len(stale) != 1 || stale[0].Reason != "line 3 is parsed now, the time record doesn't need to be added" {
+		t.Fatalf("got %+v, expected the added record to be stale", stale)
+	}
+}
+
+func TestLoadCorrections(t *testing.T) {
+	tests := []struct {
+		corrections string
+		expectedErr string
+	}{
+		{`{"corrections": [{"action": "delete", "type": "time", "record": {"event": "1", "name": "Lastname, Firstname"}}]}`, ""},
+		{`{"corrections": [{"action": "remove", "type": "time"}]}`, "correction 0: unknown action 'remove' (expected replace, delete or add)"},
+		{`{"corrections": [{"action": "delete", "type": "time", "lineNumber": 12}]}`, "correction 0: a line number needs the hash of the line"},
+		{`{"corrections": [{"action": "delete", "type": "time", "record": {"event": "1"}}, {"action": "replace", "type": "relay", "record": {"event": "2"}}]}`, "correction 1: replace needs a relay record"},
+	}
+	for _, tt := range tests {
+		filename := filepath.Join(t.TempDir(), "corrections.json")
+		if err := os.WriteFile(filename, []byte(tt.corrections), 0644); err != nil {
+			t.Fatalf("couldn't write corrections: %s", err)
+		}
+		corrections, err := LoadCorrections(filename)
+		if tt.expectedErr != "" {
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Fatalf("got error %v, expected '%s'", err, tt.expectedErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("got error: %s", err)
+		}
+		if len(corrections.Corrections) != 1 || corrections.Corrections[0].Record.Name != "Lastname, Firstname" {
+			t.Fatalf("unexpected corrections: %+v", corrections.Corrections)
+		}
+	}
+}
+
+func TestClearSources(t *testing.T) {
+	input := "Event 1  Girls 10 & Under 50 Yard Freestyle\n" +
+		"Name Age Team Seed Time Finals Time Points\n" +
+		"1 Lastname, Firstname  10 Lynchburg YMCA 33.10 32.54 9\n"
+	result, err := parsePDFText(bytes.NewBufferString(input), Options{})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	// the source shows the hash of the line for the corrections
+	expected := "page 1, line 2 (hash " + LineHash("1 Lastname, Firstname  10 Lynchburg YMCA 33.10 32.54 9") + ")"
+	if got := result.Times[0].Source.String(); got != expected {
+		t.Fatalf("got source '%s', expected '%s'", got, expected)
+	}
+	ClearSources(&result)
+	if result.Events[0].Source != nil || result.Times[0].Source != nil {
+		t.Fatalf("expected no sources, got %s and %s", result.Events[0].Source, result.Times[0].Source)
+	}
+}
@@ -0,0 +1,89 @@
+package parser
+
+const (
+	LINE_RECOGNIZED = "recognized"
+	LINE_ERRORED    = "errored"
+	LINE_IGNORED    = "ignored"
+)
+
+// Coverage counts the lines of the results sections: every line in between the table header of an event and the
+// next page or column break is either recognized (a result, split times, a round or heat marker, ...), errored
+// (a parse error) or ignored (kept in the unparsed lines of the result)
+type Coverage struct {
+	Lines    LineCounts             `json:"lines"`
+	Sections map[string]*LineCounts `json:"sections"`
+	Events   []*EventCoverage       `json:"events"`
+}
+
+type LineCounts struct {
+	Seen       int `json:"seen"`
+	Recognized int `json:"recognized"`
+	Errored    int `json:"errored"`
+	Ignored    int `json:"ignored"`
+}
+
+type EventCoverage struct {
+	Event *Event     `json:"event"`
+	Lines LineCounts `json:"lines"`
+}
+
+// UnparsedLine is a line of a results section that wasn't recognized
+type UnparsedLine struct {
+	Section    string `json:"section"`
+	Event      *Event `json:"event"`
+	LineNumber int    `json:"lineNumber"`
+	Line       string `json:"line"`
+}
+
+// add counts a line of a section. Lines in front of the first event are only counted in the section.
+func (c *Coverage) add(section string, event *Event, status string) {
+	c.Lines.add(status)
+	if c.Sections == nil {
+		c.Sections = map[string]*LineCounts{}
+	}
+	if c.Sections[section] == nil {
+		c.Sections[section] = &LineCounts{}
+	}
+	c.Sections[section].add(status)
+	if event == nil {
+		return
+	}
+	// the lines of an event are together, except when the event continues after another event
+	var eventCoverage *EventCoverage
+	for k := len(c.Events) - 1; k >= 0; k-- {
+		if c.Events[k].Event == event {
+			eventCoverage = c.Events[k]
+			break
+		}
+	}
+	if eventCoverage == nil {
+		eventCoverage = &EventCoverage{Event: event}
+		c.Events = append(c.Events, eventCoverage)
+	}
+	eventCoverage.Lines.add(status)
+}
+
+// Complete returns true when every line of the results sections was recognized
+func (c *Coverage) Complete() bool {
+	return c.Lines.Seen == c.Lines.Recognized
+}
+
+func (l *LineCounts) add(status string) {
+	l.Seen++
+	switch status {
+	case LINE_RECOGNIZED:
+		l.Recognized++
+	case LINE_ERRORED:
+		l.Errored++
+	case LINE_IGNORED:
+		l.Ignored++
+	}
+}
+
+// Percentage returns the percentage of recognized lines
+func (l LineCounts) Percentage() float64 {
+	if l.Seen == 0 {
+		return 100
+	}
+	return float64(l.Recognized) * 100 / float64(l.Seen)
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseCoverage(t *testing.T) {
+	input := "Event 1  Girls 10 & Under 50 Yard Freestyle\n" +
+		"Name Age Team Seed Time Finals Time Points\n" +
+		"1 Lastname, Firstname  10 Lynchburg YMCA 33.10 32.54 9\n" +
+		"2 Lastname, Second  9 Heritage Swim 34.00 33.80 7\n" +
+		"16.10 32.54\n" +
+		"--- Lastname, Third  10 Lynchburg YMCA 35.00 DQ\n" +
+		"Early take-off swimmer\n" +
+		"3 Lastname, Fourth  ten Heritage Swim 35.00 34.80 6\n" +
+		"\n" +
+		"Meet Results Page 2\n" +
+		"Event 2  Girls 10 & Under 200 Yard Freestyle Relay\n" +
+		"Team  Relay Seed Time Finals Time Points\n" +
+		"1 Lynchburg YMCA     A 2:30.00 2:25.10 18\n" +
+		"1) Lastname, Firstname 10 2) Lastname, Second 9 3) Lastname, Third 10 4) Lastname, Fourth 9\n"
+	res, err := parsePDFText(bytes.NewBufferString(input), Options{})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.Events) != 2 {
+		t.Fatalf("got %d events, expected 2", len(res.Events))
+	}
+	expected := Coverage{
+		Lines: LineCounts{Seen: 8, Recognized: 6, Errored: 1, Ignored: 1},
+		Sections: map[string]*LineCounts{
+			SECTION_INDIVIDUAL: {Seen: 6, Recognized: 4, Errored: 1, Ignored: 1},
+			SECTION_RELAY:      {Seen: 2, Recognized: 2},
+		},
+		Events: []*EventCoverage{
+			{Event: res.Events[0], Lines: LineCounts{Seen: 6, Recognized: 4, Errored: 1, Ignored: 1}},
+			{Event: res.Events[1], Lines: LineCounts{Seen: 2, Recognized: 2}},
+		},
+	}
+	if diff := cmp.Diff(expected, res.Coverage); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+	if res.Coverage.Complete() {
+		t.Fatalf("coverage is complete, expected an ignored and an errored line")
+	}
+	if len(res.UnparsedLines) != 1 || res.UnparsedLines[0].Line != "Early take-off swimmer" || res.UnparsedLines[0].LineNumber != 6 {
+		t.Fatalf("got unparsed lines %+v, expected line 6", res.UnparsedLines)
+	}
+}
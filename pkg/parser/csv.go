@@ -48,6 +48,11 @@ func MarshalCSV(data interface{}) ([]byte, error) {
 
 		row := make([]string, elem.NumField())
 		for j := 0; j < elem.NumField(); j++ {
+			// a value with a <Field>Printed flag is left empty when it wasn't printed (Points, PointsPrinted)
+			printed := elem.FieldByName(elemType.Field(j).Name + "Printed")
+			if printed.IsValid() && printed.Kind() == reflect.Bool && !printed.Bool() {
+				continue
+			}
 			row[j] = fmt.Sprint(elem.Field(j).Interface())
 		}
 		w.Write(row)
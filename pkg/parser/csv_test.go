@@ -1,6 +1,9 @@
 package parser
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestMarshalCSV(t *testing.T) {
 	result := &Result{
@@ -17,3 +20,22 @@ func TestMarshalCSV(t *testing.T) {
 		t.Fatalf("error: %s", err)
 	}
 }
+
+func TestMarshalCSVPointsPrinted(t *testing.T) {
+	relayTimes := []*RelayTime{
+		{TeamName: "T1", Points: 0},
+		{TeamName: "T2", Points: 0, PointsPrinted: true},
+	}
+	out, err := MarshalCSV(relayTimes)
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	points := strings.Index(lines[0], ",Points,")
+	columns := strings.Count(lines[0][:points], ",") + 1
+	for k, expected := range []string{"", "0"} {
+		if got := strings.Split(lines[k+1], ",")[columns]; got != expected {
+			t.Fatalf("%s: got points '%s', expected '%s'", relayTimes[k].TeamName, got, expected)
+		}
+	}
+}
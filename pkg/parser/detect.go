@@ -0,0 +1,168 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// weights of the evidence of a layout: a banner counts once, the header and event lines count every time
+const (
+	DETECT_BANNER_WEIGHT = 10
+	DETECT_HEADER_WEIGHT = 2
+	DETECT_EVENT_WEIGHT  = 1
+)
+
+// layoutSignals are the phrases and line shapes of a layout
+type layoutSignals struct {
+	name     string
+	fileType string
+	// the format definition of the layouts without a line parser, compiled on first use
+	format func() (*Format, error)
+	// license banners and the file type line of the extractor
+	banners []*regexp.Regexp
+	// table headers of the results
+	headers []*regexp.Regexp
+	// event headers
+	events []*regexp.Regexp
+}
+
+// supportedLayouts are the layouts of the built-in formats. The first layout wins a tie.
+var supportedLayouts = []*layoutSignals{
+	{
+		name:     "HY-TEK Meet Manager",
+		fileType: FILETYPE_TYPE1,
+		banners: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)HY-TEK'?s MEET MANAGER`),
+		},
+		headers: []*regexp.Regexp{
+			regexp.MustCompile(`^\s*Name\s+(?:Age|Ag\s+e|Yr|YOB|Year)\s`),
+			regexp.MustCompile(`^\s*Team\s{2,}Relay`),
+		},
+		events: []*regexp.Regexp{
+			// line: Event 1  Girls 11-12 50 Yard Butterfly
+			// line: (Event 12  Girls 10 & Under 50 Yard Freestyle)
+			regexp.MustCompile(`^\s*\(?[Ee]vent\s+\d+[^,]*$`),
+		},
+	},
+	{
+		name:     FILETYPE_TYPE2,
+		fileType: FILETYPE_TYPE2,
+		banners: []*regexp.Regexp{
+			regexp.MustCompile(`^FileType: ` + FILETYPE_TYPE2 + `$`),
+			regexp.MustCompile(`SwimTopia`),
+		},
+		headers: []*regexp.Regexp{
+			regexp.MustCompile(`^\s*Pl\s+Name\s+Age\s+Team`),
+			regexp.MustCompile(`^\s*Pl\s+Team\s+Relay`),
+		},
+		events: []*regexp.Regexp{
+			// line: #1 Mixed 6 & Under 25yd Freestyle
+			regexp.MustCompile(`^\s*#\d+\s+\S`),
+		},
+	},
+	{
+		name:     FILETYPE_SPLASH,
+		fileType: FILETYPE_SPLASH,
+		format:   splashFormat,
+		banners: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)Splash Meet Manager`),
+		},
+		headers: []*regexp.Regexp{
+			regexp.MustCompile(`^\s*(?:Rank|Rk\.?|Place)\s+Name\s+(?:YoB|Year)`),
+		},
+		events: []*regexp.Regexp{
+			// line: Event 5  Men, 100m Freestyle
+			regexp.MustCompile(`^\s*Event\s+\d+\s+(?:Women|Men|Mixed|Girls|Boys)[^,]*,\s*(?:4\s*x\s*)?\d+\s?m\b`),
+		},
+	},
+}
+
+// FormatCandidate is the score of a layout for a document. The confidence is the share of the score in
+// the scores of all layouts.
+type FormatCandidate struct {
+	Format     string   `json:"format"`
+	Score      int      `json:"score"`
+	Confidence float64  `json:"confidence"`
+	Evidence   []string `json:"evidence"`
+	fileType   string
+	format     func() (*Format, error)
+}
+
+// Detection is the layout of a document: the winner, the runner-up and the scores of every layout, best first
+type Detection struct {
+	Winner     *FormatCandidate   `json:"winner"`
+	RunnerUp   *FormatCandidate   `json:"runnerUp,omitempty"`
+	Candidates []*FormatCandidate `json:"candidates"`
+}
+
+func (d *Detection) String() string {
+	s := fmt.Sprintf("%s (confidence %.0f%%)", d.Winner.Format, d.Winner.Confidence*100)
+	if d.RunnerUp != nil {
+		s += fmt.Sprintf(", runner-up %s (confidence %.0f%%)", d.RunnerUp.Format, d.RunnerUp.Confidence*100)
+	}
+	return s
+}
+
+// detectFormat scores the lines against every supported layout. It fails when the lines have no banner,
+// results header or event header of any layout.
+func detectFormat(lines []*textLine) (*Detection, error) {
+	detection := &Detection{}
+	total := 0
+	for _, layout := range supportedLayouts {
+		candidate := layout.score(lines)
+		total += candidate.Score
+		detection.Candidates = append(detection.Candidates, candidate)
+	}
+	sort.SliceStable(detection.Candidates, func(i, j int) bool {
+		return detection.Candidates[i].Score > detection.Candidates[j].Score
+	})
+	if total == 0 {
+		names := []string{}
+		for _, layout := range supportedLayouts {
+			names = append(names, layout.name)
+		}
+		return detection, fmt.Errorf("the text doesn't fit any supported format (%s): no banner, results header or event header found", strings.Join(names, ", "))
+	}
+	for _, candidate := range detection.Candidates {
+		candidate.Confidence = float64(candidate.Score) / float64(total)
+	}
+	detection.Winner = detection.Candidates[0]
+	if len(detection.Candidates) > 1 && detection.Candidates[1].Score > 0 {
+		detection.RunnerUp = detection.Candidates[1]
+	}
+	return detection, nil
+}
+
+// score returns the score of the layout for the lines, with the evidence found
+func (l *layoutSignals) score(lines []*textLine) *FormatCandidate {
+	candidate := &FormatCandidate{Format: l.name, Evidence: []string{}, fileType: l.fileType, format: l.format}
+	banners := map[*regexp.Regexp]bool{}
+	headers := 0
+	events := 0
+	for _, textLine := range lines {
+		line := textLine.Text
+		for _, banner := range l.banners {
+			if !banners[banner] && banner.MatchString(line) {
+				banners[banner] = true
+				candidate.Score += DETECT_BANNER_WEIGHT
+				candidate.Evidence = append(candidate.Evidence, fmt.Sprintf("banner '%s' on line %d", banner.FindString(line), textLine.LineNumber))
+			}
+		}
+		if matchesAny(l.headers, line) {
+			headers++
+		}
+		if matchesAny(l.events, line) {
+			events++
+		}
+	}
+	candidate.Score += headers*DETECT_HEADER_WEIGHT + events*DETECT_EVENT_WEIGHT
+	if headers > 0 {
+		candidate.Evidence = append(candidate.Evidence, fmt.Sprintf("%d results headers", headers))
+	}
+	if events > 0 {
+		candidate.Evidence = append(candidate.Evidence, fmt.Sprintf("%d event headers", events))
+	}
+	return candidate
+}
@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		winner   string
+		runnerUp string
+		// minimum confidence of the winner
+		confidence float64
+	}{
+		{
+			name: "hytek banner",
+			input: "HY-TEK's MEET MANAGER 8.0 - 10:02 AM  6/14/2025 Page 1\n" +
+				"Event 1  Girls 11-12 50 Yard Butterfly\n" +
+				"Name Age Team Seed Time Finals Time Points\n" +
+				"1 Lastname, Firstname 12 Lynchburg YMCA 33.10 32.54 9\n",
+			winner:     "HY-TEK Meet Manager",
+			confidence: 1,
+		},
+		{
+			name: "swimtopia file type",
+			input: "FileType: SwimTopia Meet Maestro\n" +
+				"#1 Mixed 6 & Under 25yd Freestyle\n" +
+				"Pl Name Age Team Seed Time\n",
+			winner:     FILETYPE_TYPE2,
+			confidence: 1,
+		},
+		{
+			// the event headers of SwimTopia without the file type line of the extractor
+			name: "swimtopia event lines",
+			input: "Summer League Meet\n" +
+				"#1 Girls 6 & Under 25 Yard Freestyle\n" +
+				"Pl Name Age Team Seed Time\n" +
+				"#2 Boys 6 & Under 25 Yard Freestyle\n" +
+				"Event 3 was cancelled\n",
+			winner:     FILETYPE_TYPE2,
+			runnerUp:   "HY-TEK Meet Manager",
+			confidence: 0.8,
+		},
+		{
+			name: "hytek without banner",
+			input: "Event 1  Girls 11-12 50 Yard Butterfly\n" +
+				"Name Age Team Seed Time Finals Time Points\n" +
+				"(Event 1  Girls 11-12 50 Yard Butterfly)\n" +
+				"Event 2  Boys 11-12 200 Yard Freestyle Relay\n" +
+				"Team  Relay Seed Time Finals Time Points\n",
+			winner:     "HY-TEK Meet Manager",
+			confidence: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := []*textLine{}
+			for k, line := range strings.Split(tt.input, "\n") {
+				lines = append(lines, &textLine{Text: line, LineNumber: k})
+			}
+			detection, err := detectFormat(lines)
+			if err != nil {
+				t.Fatalf("got error: %s", err)
+			}
+			if detection.Winner.Format != tt.winner || detection.Winner.Confidence < tt.confidence {
+				t.Fatalf("got %s, expected %s with a confidence of at least %.2f", detection, tt.winner, tt.confidence)
+			}
+			runnerUp := ""
+			if detection.RunnerUp != nil {
+				runnerUp = detection.RunnerUp.Format
+			}
+			if runnerUp != tt.runnerUp {
+				t.Fatalf("got runner-up '%s', expected '%s'", runnerUp, tt.runnerUp)
+			}
+		})
+	}
+}
+
+func TestParsePDFTextNoFormat(t *testing.T) {
+	input := "Summer League Meet\n" +
+		"1 Lastname, Firstname 6 PFP 18.14 18.39\n"
+	result, err := parsePDFText(bytes.NewBufferString(input), Options{})
+	expectedErr := "the text doesn't fit any supported format (HY-TEK Meet Manager, SwimTopia Meet Maestro, Splash Meet Manager): no banner, results header or event header found"
+	if err == nil || err.Error() != expectedErr {
+		t.Fatalf("got error %v, expected '%s'", err, expectedErr)
+	}
+	if result.Detection == nil || len(result.Detection.Candidates) != len(supportedLayouts) || len(result.Times) != 0 {
+		t.Fatalf("expected the scores of every layout and no times, got %+v", result)
+	}
+}
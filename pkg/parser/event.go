@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -42,22 +43,22 @@ func processEventType2(line string) (*Event, error) {
 	// line: 6 & Under 100yd Freestyle Relay
 	event.AgeGroup, err = parseEventAgeGroup(line)
 	if err != nil {
-		return nil, fmt.Errorf("parse age group error: %s", err)
+		return event, fmt.Errorf("parse age group error: %s", err)
 	}
 	if event.AgeGroup != "" { // we might have an empty age group if the event doesn't have it
-		line = line[len(event.AgeGroup)+1:]
+		line = afterField(line, len(event.AgeGroup))
 	}
 	event.AgeGroup = normalizeAge(event.AgeGroup)
 	// line: 100yd Freestyle Relay
 	event.Distance, err = parseEventDistance(line)
 	if err != nil {
-		return nil, fmt.Errorf("parse distance error: %s", err)
+		return event, fmt.Errorf("parse distance error: %s", err)
 	}
-	line = line[len(event.Distance)+1:]
+	line = afterField(line, len(event.Distance))
 	// line: 100yd Freestyle Relay
 	event.Stroke, event.Relay, err = parseStroke(line)
 	if err != nil {
-		return nil, fmt.Errorf("parse stroke error: %s", err)
+		return event, fmt.Errorf("parse stroke error: %s", err)
 	}
 
 	return event, nil
@@ -69,17 +70,17 @@ func processEventType1(line string) (*Event, error) {
 		QualifyingTimes: make(map[string]string),
 	}
 	if strings.HasPrefix(line, "(Event") || strings.HasPrefix(line, "(event") {
-		line = line[len("(Event")+1:]
+		line = afterField(line, len("(Event"))
 	} else if strings.HasPrefix(line, "Event") || strings.HasPrefix(line, "event") {
-		line = line[len("Event")+1:]
+		line = afterField(line, len("Event"))
 	} else if strings.HasPrefix(line, "#") {
 		line = line[len("#"):]
 	} else {
-		return nil, fmt.Errorf("can't find event. Output: %s", line)
+		return event, fmt.Errorf("can't find event. Output: %s", line)
 	}
 	eventSplit := strings.Split(line, "  ")
 	if len(eventSplit) < 2 {
-		return nil, fmt.Errorf("event # not found")
+		return event, fmt.Errorf("event # not found")
 	}
 	// eventSplit[0]: 57
 	event.Round = eventSplit[0]
@@ -88,31 +89,39 @@ func processEventType1(line string) (*Event, error) {
 	var err error
 	event.Gender, err = parseGender(eventSplit[1], false)
 	if err != nil {
-		return nil, fmt.Errorf("can't extract gender from: %s", line)
+		return event, fmt.Errorf("can't extract gender from: %s", line)
 	}
 	index += len(event.Gender) + 1
 	// eventSplit[1]: 10 & Under 50 LC Meter Butterfly)
 	event.AgeGroup, err = parseEventAgeGroup(eventSplit[1][index:])
 	if err != nil {
-		return nil, fmt.Errorf("error: %s", err)
+		return event, fmt.Errorf("error: %s", err)
 	}
 	if event.AgeGroup != "" { // we might have an empty age group if the event doesn't have it
-		index += len(event.AgeGroup) + 1
+		index = min(index+len(event.AgeGroup)+1, len(eventSplit[1]))
 	}
 	event.AgeGroup = normalizeAge(event.AgeGroup)
 	event.Distance, err = parseEventDistance(eventSplit[1][index:])
 	if err != nil {
-		return nil, fmt.Errorf("can't extract distance from from: %s", eventSplit[1][index:])
+		return event, fmt.Errorf("can't extract distance from from: %s", eventSplit[1][index:])
 	}
-	index += len(event.Distance) + 1
+	index = min(index+len(event.Distance)+1, len(eventSplit[1]))
 	// eventSplit[1]: Butterfly)
 	event.Stroke, event.Relay, err = parseStroke(eventSplit[1][index:])
 	if err != nil {
-		return nil, fmt.Errorf("can't extract stroke from: %s", err)
+		return event, fmt.Errorf("can't extract stroke from: %s", err)
 	}
 	return event, nil
 }
 
+// afterField returns the line after a field of the given length and the space that follows it
+func afterField(line string, length int) string {
+	if length+1 > len(line) {
+		return ""
+	}
+	return line[length+1:]
+}
+
 func parseStroke(stroke string) (string, bool, error) {
 	if strings.HasPrefix(stroke, "Medley Relay") {
 		return "Medley", true, nil
@@ -239,3 +248,88 @@ func eventAddQualifyingTimes(event *Event, line string) error {
 
 	return nil
 }
+
+const (
+	COURSE_SCY = "SCY"
+	COURSE_SCM = "SCM"
+	COURSE_LCM = "LCM"
+)
+
+var courseDistanceRegex = regexp.MustCompile(`(?i)^(\d+)\s*(LC|SC)?\s*(Meter|Yard|yd|m)?`)
+
+// parseCourse splits an event distance (200 Yard, 100yd, 50 LC Meter) into the distance and course
+func parseCourse(distance string) (int, string) {
+	match := courseDistanceRegex.FindStringSubmatch(strings.TrimSpace(distance))
+	if match == nil {
+		return 0, ""
+	}
+	length, _ := strconv.Atoi(match[1])
+	unit := strings.ToLower(match[3])
+	switch {
+	case unit == "yard" || unit == "yd":
+		return length, COURSE_SCY
+	case strings.EqualFold(match[2], "SC"):
+		return length, COURSE_SCM
+	case strings.EqualFold(match[2], "LC"):
+		return length, COURSE_LCM
+	case unit == "meter":
+		return length, COURSE_LCM
+	}
+	// a bare m (100m) is used by short course and long course meets alike
+	return length, ""
+}
+
+// normalizeStroke maps the stroke names found in event headers to one name per stroke
+func normalizeStroke(stroke string) string {
+	switch strings.ToLower(stroke) {
+	case "fly", "fl", "butterfly":
+		return "Butterfly"
+	case "back", "bk", "backstroke":
+		return "Backstroke"
+	case "breast", "br", "breaststroke":
+		return "Breaststroke"
+	case "free", "fr", "freestyle":
+		return "Freestyle"
+	case "im", "medley":
+		return "IM"
+	}
+	return stroke
+}
+
+// normalizeGender maps women/men to girls/boys so age group and open events can be compared
+func normalizeGender(gender string) string {
+	switch strings.ToLower(gender) {
+	case "women", "female", "f", "w":
+		return "girls"
+	case "men", "male", "m":
+		return "boys"
+	}
+	return strings.ToLower(gender)
+}
+
+var ageRangeRegex = regexp.MustCompile(`^(\d{1,2})\s*-\s*(\d{1,2})$`)
+var ageUnderRegex = regexp.MustCompile(`^(\d{1,2})\s*&\s*under$`)
+var ageOverRegex = regexp.MustCompile(`^(\d{1,2})\s*&\s*(?:over|o)$`)
+
+// ageGroupContains checks whether age falls in a (normalized) age group like 11-12, 10 & under or 15 & over.
+// An empty age group is an open event and contains every age.
+func ageGroupContains(ageGroup string, age int) (bool, error) {
+	ageGroup = strings.ToLower(strings.TrimSpace(ageGroup))
+	if ageGroup == "" || ageGroup == "open" {
+		return true, nil
+	}
+	if match := ageRangeRegex.FindStringSubmatch(ageGroup); match != nil {
+		low, _ := strconv.Atoi(match[1])
+		high, _ := strconv.Atoi(match[2])
+		return age >= low && age <= high, nil
+	}
+	if match := ageUnderRegex.FindStringSubmatch(ageGroup); match != nil {
+		high, _ := strconv.Atoi(match[1])
+		return age <= high, nil
+	}
+	if match := ageOverRegex.FindStringSubmatch(ageGroup); match != nil {
+		low, _ := strconv.Atoi(match[1])
+		return age >= low, nil
+	}
+	return false, fmt.Errorf("unknown age group: %s", ageGroup)
+}
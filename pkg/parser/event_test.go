@@ -185,3 +185,25 @@ func TestParseEventAgeGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCourse(t *testing.T) {
+	tests := []struct {
+		input    string
+		distance int
+		course   string
+	}{
+		{"200 Yard", 200, COURSE_SCY},
+		{"100yd", 100, COURSE_SCY},
+		{"50 SC Meter", 50, COURSE_SCM},
+		{"50 LC Meter", 50, COURSE_LCM},
+		{"400 Meter", 400, COURSE_LCM},
+		// a bare m doesn't say the course of the pool
+		{"400m", 400, ""},
+	}
+	for _, tt := range tests {
+		distance, course := parseCourse(tt.input)
+		if distance != tt.distance || course != tt.course {
+			t.Fatalf("%s: got %d %s, expected %d %s", tt.input, distance, course, tt.distance, tt.course)
+		}
+	}
+}
@@ -0,0 +1,723 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// records of the line rules of a format definition
+const (
+	RECORD_INDIVIDUAL     = "individual"
+	RECORD_RELAY          = "relay"
+	RECORD_RELAY_SWIMMERS = "relaySwimmers"
+	RECORD_SPLITS         = "splits"
+	RECORD_HEAT           = "heat"
+	RECORD_ROUND          = "round"
+	RECORD_IGNORE         = "ignore"
+)
+
+// FormatDefinition describes a results layout without Go code. The event pattern starts an event, the results of
+// the event are parsed from the lines in between a section start and a section end marker (or from the event on, when
+// there are no section start markers) with the first line rule that matches.
+type FormatDefinition struct {
+	Name string `json:"name"`
+	// patterns of the lines in front of the results of an event (the column header)
+	SectionStart []string `json:"sectionStart,omitempty"`
+	// patterns of the lines after the results of an event (team scores, records)
+	SectionEnd []string  `json:"sectionEnd,omitempty"`
+	Event      *LineRule `json:"event"`
+	// line rules of the results, the first rule that matches parses the line. The individual and splits rules
+	// apply to the lines of individual events, the relay and relaySwimmers rules to the lines of relay events.
+	Lines []*LineRule `json:"lines"`
+}
+
+// LineRule parses a line with a regular expression. The named groups of the pattern are the fields of the record,
+// the post-processors of a group change the value of the group in order.
+// line rule: {"record": "individual", "pattern": "^(?P<place>\\d+)\\s+(?P<name>.+?)\\s+(?P<time>\\d+\\.\\d{2})$"}
+type LineRule struct {
+	Name string `json:"name,omitempty"`
+	// individual, relay, relaySwimmers, splits, heat, round or ignore. Empty for the event rule.
+	Record  string              `json:"record,omitempty"`
+	Pattern string              `json:"pattern"`
+	Process map[string][]string `json:"process,omitempty"`
+}
+
+// Format is a validated format definition, ready to parse the lines of a document
+type Format struct {
+	Name         string
+	sectionStart []*regexp.Regexp
+	sectionEnd   []*regexp.Regexp
+	event        *formatRule
+	lines        []*formatRule
+}
+
+type formatRule struct {
+	name    string
+	record  string
+	pattern *regexp.Regexp
+	process map[string][]func(string) string
+}
+
+// groups of the records: the required groups first
+var formatGroups = map[string][]string{
+	"":                    {"number", "gender", "ageGroup", "distance", "stroke", "relay", "legs", "type"},
+	RECORD_INDIVIDUAL:     {"name", "time", "place", "age", "yearOfBirth", "grade", "team", "lsc", "heat", "lane", "seedTime", "prelimTime", "points", "worldAquaticsPoints", "qualifyingStandards", "achievements"},
+	RECORD_RELAY:          {"team", "time", "place", "lsc", "teamShort", "relay", "heat", "seedTime", "points", "worldAquaticsPoints", "qualifyingStandards", "achievements"},
+	RECORD_RELAY_SWIMMERS: {"name", "leg", "age", "yearOfBirth"},
+	RECORD_SPLITS:         {"splits"},
+	RECORD_HEAT:           {"heat"},
+	RECORD_ROUND:          {"round"},
+	RECORD_IGNORE:         {},
+}
+
+var formatRequiredGroups = map[string]int{
+	"":                    1,
+	RECORD_INDIVIDUAL:     2,
+	RECORD_RELAY:          2,
+	RECORD_RELAY_SWIMMERS: 1,
+	RECORD_HEAT:           1,
+	RECORD_ROUND:          1,
+}
+
+var parenthesesRegex = regexp.MustCompile(`\([^)]*\)`)
+
+var postProcessors = map[string]func(string) string{
+	"trim":           strings.TrimSpace,
+	"lower":          strings.ToLower,
+	"upper":          strings.ToUpper,
+	"title":          titleCase,
+	"collapseSpaces": collapseSpaces,
+	"surnameFirst":   surnameFirst,
+	"gender":         normalizeGender,
+	"stroke":         normalizeStroke,
+	"ageGroup":       func(s string) string { return normalizeAge(strings.ToLower(s)) },
+	// times and points with a decimal comma: 1:02,34
+	"decimalComma": func(s string) string { return strings.ReplaceAll(s, ",", ".") },
+	// lap times in between the cumulative split times: 50m: 25.10  100m: 52.34 (27.24)
+	"dropParentheses": func(s string) string { return collapseSpaces(parenthesesRegex.ReplaceAllString(s, "")) },
+}
+
+// LoadFormat loads a format definition from a json file
+func LoadFormat(filePath string) (*Format, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	definition := &FormatDefinition{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(definition); err != nil {
+		return nil, fmt.Errorf("couldn't parse format definition: %s", err)
+	}
+	return CompileFormat(definition)
+}
+
+// CompileFormat validates a format definition. The error names the rule that isn't valid.
+func CompileFormat(definition *FormatDefinition) (*Format, error) {
+	if definition.Name == "" {
+		return nil, fmt.Errorf("name: the format needs a name")
+	}
+	format := &Format{Name: definition.Name}
+	for k, pattern := range definition.SectionStart {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("sectionStart[%d]: %s", k, err)
+		}
+		format.sectionStart = append(format.sectionStart, compiled)
+	}
+	for k, pattern := range definition.SectionEnd {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("sectionEnd[%d]: %s", k, err)
+		}
+		format.sectionEnd = append(format.sectionEnd, compiled)
+	}
+	if definition.Event == nil {
+		return nil, fmt.Errorf("event: the format needs an event rule")
+	}
+	if definition.Event.Record != "" {
+		return nil, fmt.Errorf("event: the event rule doesn't have a record")
+	}
+	var err error
+	format.event, err = compileLineRule(definition.Event)
+	if err != nil {
+		return nil, fmt.Errorf("event: %s", err)
+	}
+	if len(definition.Lines) == 0 {
+		return nil, fmt.Errorf("lines: the format needs at least one line rule")
+	}
+	for k, lineRule := range definition.Lines {
+		path := fmt.Sprintf("lines[%d]", k)
+		if lineRule.Name != "" {
+			path += " (" + lineRule.Name + ")"
+		}
+		if _, ok := formatGroups[lineRule.Record]; !ok || lineRule.Record == "" {
+			return nil, fmt.Errorf("%s: unknown record '%s' (expected individual, relay, relaySwimmers, splits, heat, round or ignore)", path, lineRule.Record)
+		}
+		rule, err := compileLineRule(lineRule)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err)
+		}
+		format.lines = append(format.lines, rule)
+	}
+	return format, nil
+}
+
+func compileLineRule(lineRule *LineRule) (*formatRule, error) {
+	if lineRule.Pattern == "" {
+		return nil, fmt.Errorf("pattern: the rule needs a pattern")
+	}
+	pattern, err := regexp.Compile(lineRule.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("pattern: %s", err)
+	}
+	rule := &formatRule{name: lineRule.Name, record: lineRule.Record, pattern: pattern, process: map[string][]func(string) string{}}
+	if rule.name == "" {
+		rule.name = lineRule.Record
+	}
+	if rule.name == "" {
+		rule.name = RULE_EVENT
+	}
+
+	groups := formatGroups[lineRule.Record]
+	found := map[string]bool{}
+	for _, group := range pattern.SubexpNames() {
+		if group == "" {
+			continue
+		}
+		if !containsString(groups, group) {
+			return nil, fmt.Errorf("pattern: unknown group '%s' (expected %s)", group, strings.Join(groups, ", "))
+		}
+		found[group] = true
+	}
+	for _, group := range groups[0:formatRequiredGroups[lineRule.Record]] {
+		if !found[group] {
+			return nil, fmt.Errorf("pattern: missing group '%s'", group)
+		}
+	}
+
+	// sorted, so the first invalid group is the same every time
+	processed := []string{}
+	for group := range lineRule.Process {
+		processed = append(processed, group)
+	}
+	sort.Strings(processed)
+	for _, group := range processed {
+		if !found[group] {
+			return nil, fmt.Errorf("process.%s: the pattern doesn't have the group", group)
+		}
+		for k, name := range lineRule.Process[group] {
+			postProcessor, err := compilePostProcessor(name)
+			if err != nil {
+				return nil, fmt.Errorf("process.%s[%d]: %s", group, k, err)
+			}
+			rule.process[group] = append(rule.process[group], postProcessor)
+		}
+	}
+	return rule, nil
+}
+
+// compilePostProcessor returns the post-processor of a name, or of replace:old:new
+func compilePostProcessor(name string) (func(string) string, error) {
+	if strings.HasPrefix(name, "replace:") {
+		parts := strings.Split(name, ":")
+		if len(parts) != 3 || parts[1] == "" {
+			return nil, fmt.Errorf("replace needs the text to replace and the replacement: replace:old:new")
+		}
+		return func(s string) string { return strings.ReplaceAll(s, parts[1], parts[2]) }, nil
+	}
+	postProcessor, ok := postProcessors[name]
+	if !ok {
+		names := []string{}
+		for name := range postProcessors {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown post-processor '%s' (expected %s or replace:old:new)", name, strings.Join(names, ", "))
+	}
+	return postProcessor, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// appliesTo returns false for the individual rules in a relay event and the relay rules in an individual event
+func (r *formatRule) appliesTo(event *Event) bool {
+	switch r.record {
+	case RECORD_INDIVIDUAL, RECORD_SPLITS:
+		return event == nil || !event.Relay
+	case RECORD_RELAY, RECORD_RELAY_SWIMMERS:
+		return event == nil || event.Relay
+	}
+	return true
+}
+
+// match returns the processed groups of the line, nil when the pattern doesn't match
+func (r *formatRule) match(line string) map[string]string {
+	match := r.pattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+	return r.groups(match)
+}
+
+// groups returns the processed named groups of a match
+func (r *formatRule) groups(match []string) map[string]string {
+	fields := map[string]string{}
+	for k, group := range r.pattern.SubexpNames() {
+		if group == "" || k >= len(match) {
+			continue
+		}
+		value := strings.TrimSpace(match[k])
+		for _, postProcessor := range r.process[group] {
+			value = postProcessor(value)
+		}
+		fields[group] = value
+	}
+	return fields
+}
+
+// titleCase writes the first letter of every word (and of every part of a word with a hyphen) as a capital
+// name: VAN DER BERG-SMIT -> Van Der Berg-Smit
+func titleCase(s string) string {
+	runes := []rune(strings.ToLower(s))
+	for k := range runes {
+		if k == 0 || runes[k-1] == ' ' || runes[k-1] == '-' || runes[k-1] == '\'' {
+			runes[k] = unicode.ToUpper(runes[k])
+		}
+	}
+	return string(runes)
+}
+
+// surnameFirst turns a name with the surname in capitals into the Lastname, Firstname of the other formats
+// name: VAN DER BERG Anna Maria -> Van Der Berg, Anna Maria
+func surnameFirst(s string) string {
+	words := strings.Fields(s)
+	surname := 0
+	for surname < len(words) && isUpperWord(words[surname]) {
+		surname++
+	}
+	if surname == 0 || surname == len(words) {
+		return strings.Join(words, " ")
+	}
+	return titleCase(strings.Join(words[0:surname], " ")) + ", " + strings.Join(words[surname:], " ")
+}
+
+// isUpperWord returns true for a word with letters that are all capitals
+func isUpperWord(word string) bool {
+	letters := false
+	for _, r := range word {
+		if unicode.IsLower(r) {
+			return false
+		}
+		letters = letters || unicode.IsUpper(r)
+	}
+	return letters
+}
+
+// formatParser holds the state of the parser of a format definition while it reads the lines of a document
+type formatParser struct {
+	*lineParser
+	format *Format
+	// the results of an event are parsed
+	open bool
+}
+
+// parseLines parses the lines of a document with the rules of the format.
+// In strict mode, the first error or warning is returned with the result up to the line of the error.
+func (f *Format) parseLines(lines []*textLine, options Options) (Result, error) {
+	p := &formatParser{
+		lineParser: &lineParser{
+			options: options,
+			result: Result{
+				Times:         []*SwimmerTime{},
+				RelayTimes:    []*RelayTime{},
+				Events:        []*Event{},
+				ParseErrors:   []*ParseError{},
+				UnparsedLines: []*UnparsedLine{},
+			},
+			page:  1,
+			round: ROUND_TIMED_FINAL,
+		},
+		format: f,
+	}
+	for _, textLine := range lines {
+		parseErrors := len(p.result.ParseErrors)
+		p.parseLineRecover(textLine)
+		if options.Mode == MODE_STRICT {
+			for _, parseError := range p.result.ParseErrors[parseErrors:] {
+				if parseError.Severity != SEVERITY_INFO {
+					linkRounds(p.result.Times)
+					return p.result, parseError
+				}
+			}
+		}
+	}
+
+	linkRounds(p.result.Times)
+
+	return p.result, nil
+}
+
+// parseLineRecover parses the line, and records a panic as a parse error
+func (p *formatParser) parseLineRecover(textLine *textLine) {
+	defer p.recoverLine(textLine)
+	p.parseLine(textLine)
+}
+
+// section returns the section of the results of the open event
+func (p *formatParser) section() string {
+	if p.event != nil && p.event.Relay {
+		return SECTION_RELAY
+	}
+	return SECTION_INDIVIDUAL
+}
+
+func (p *formatParser) parseLine(textLine *textLine) {
+	line := textLine.Text
+	if match := pageNumberRegex.FindStringSubmatch(line); match != nil {
+		p.page, _ = strconv.Atoi(match[1])
+	}
+	var source *Source
+	if !p.options.OmitSource {
+		source = lineSource(textLine, p.page)
+	}
+	state := "none"
+	if p.open {
+		state = p.section()
+	}
+	p.trace(textLine, TraceEvent{Kind: TRACE_LINE, Value: line, Message: state})
+
+	if fields := p.format.event.match(line); fields != nil {
+		p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: p.format.event.name})
+		p.parseEvent(textLine, fields, source)
+		return
+	}
+	if matchesAny(p.format.sectionStart, line) {
+		p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: "section start"})
+		p.open = p.event != nil
+		return
+	}
+	if matchesAny(p.format.sectionEnd, line) {
+		p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: "section end"})
+		p.open = false
+		return
+	}
+	if !p.open || strings.TrimSpace(line) == "" || pageNumberRegex.MatchString(line) {
+		return
+	}
+
+	section := p.section()
+	parseErrors := len(p.result.ParseErrors)
+	rule := ""
+	for _, lineRule := range p.format.lines {
+		if !lineRule.appliesTo(p.event) {
+			continue
+		}
+		match := lineRule.pattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		rule = lineRule.name
+		p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: rule})
+		p.parseRecord(textLine, lineRule, match, source)
+		break
+	}
+
+	errored := false
+	for _, parseError := range p.result.ParseErrors[parseErrors:] {
+		if parseError.Severity != SEVERITY_INFO {
+			errored = true
+			p.trace(textLine, TraceEvent{Kind: TRACE_REJECT, Rule: rule, Message: parseError.ErrorMessage})
+		}
+	}
+	status := LINE_RECOGNIZED
+	switch {
+	case errored:
+		status = LINE_ERRORED
+	case rule == "":
+		status = LINE_IGNORED
+		p.trace(textLine, TraceEvent{Kind: TRACE_REJECT, Message: "no rule of format " + p.format.Name + " matched the line in the " + section + " section"})
+		unparsedLine := UnparsedLine{
+			Section:    section,
+			Event:      p.event,
+			LineNumber: textLine.LineNumber,
+			Line:       line,
+		}
+		p.result.UnparsedLines = append(p.result.UnparsedLines, &unparsedLine)
+	}
+	p.result.Coverage.add(section, p.event, status)
+}
+
+func matchesAny(patterns []*regexp.Regexp, line string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEvent starts an event. Without section start markers, the results follow the event line.
+func (p *formatParser) parseEvent(textLine *textLine, fields map[string]string, source *Source) {
+	p.round = ROUND_TIMED_FINAL
+	p.heat = ""
+	p.relay = nil
+	p.event = &Event{
+		Round:           fields["number"],
+		Type:            fields["type"],
+		Gender:          fields["gender"],
+		AgeGroup:        fields["ageGroup"],
+		Distance:        fields["distance"],
+		Stroke:          fields["stroke"],
+		Relay:           fields["relay"] != "" || fields["legs"] != "",
+		QualifyingTimes: make(map[string]string),
+		Source:          source,
+	}
+	// the distance of the relay is the distance of all legs, like the events of HY-TEK (200 Yard Freestyle Relay)
+	if fields["legs"] != "" {
+		distance, err := relayDistance(fields["legs"], p.event.Distance)
+		if err != nil {
+			parseError := ParseError{
+				Type:         "Event",
+				Severity:     SEVERITY_WARNING,
+				LineNumber:   textLine.LineNumber,
+				Line:         textLine.Text,
+				ErrorMessage: err.Error(),
+			}
+			p.result.ParseErrors = append(p.result.ParseErrors, &parseError)
+		}
+		p.event.Distance = distance
+	}
+	p.traceFields(textLine, p.event)
+	if p.event.Round == "" {
+		parseError := ParseError{
+			Type:         "Event",
+			Severity:     SEVERITY_ERROR,
+			LineNumber:   textLine.LineNumber,
+			Line:         textLine.Text,
+			ErrorMessage: "event number is empty",
+		}
+		p.result.ParseErrors = append(p.result.ParseErrors, &parseError)
+		if p.options.Mode != MODE_LENIENT {
+			p.event = nil
+			p.open = false
+			return
+		}
+		p.event.Uncertain = true
+	}
+	p.result.Events = append(p.result.Events, p.event)
+	p.open = len(p.format.sectionStart) == 0
+}
+
+var legDistanceRegex = regexp.MustCompile(`^(\d+)(.*)$`)
+
+// relayDistance returns the distance of a relay from the number of legs and the distance of a leg: 4 x 100m is 400m.
+// The distance of a leg is returned when it can't be multiplied.
+func relayDistance(legs string, legDistance string) (string, error) {
+	count, err := strconv.Atoi(legs)
+	if err != nil {
+		return legDistance, fmt.Errorf("relay legs '%s' is not a number", legs)
+	}
+	match := legDistanceRegex.FindStringSubmatch(strings.TrimSpace(legDistance))
+	if match == nil {
+		return legDistance, fmt.Errorf("relay leg distance '%s' doesn't start with a number", legDistance)
+	}
+	length, _ := strconv.Atoi(match[1])
+	return strconv.Itoa(count*length) + match[2], nil
+}
+
+// parseRecord adds the record of a line rule to the result
+func (p *formatParser) parseRecord(textLine *textLine, rule *formatRule, match []string, source *Source) {
+	line := textLine.Text
+	fields := rule.groups(match)
+	for _, group := range rule.pattern.SubexpNames() {
+		if group != "" {
+			p.trace(textLine, TraceEvent{Kind: TRACE_FIELD, Field: group, Value: fields[group]})
+		}
+	}
+	addError := func(errorType string, err error, partial *SwimmerTime) {
+		parseError := ParseError{
+			Type:               errorType,
+			Severity:           SEVERITY_ERROR,
+			PartialSwimmerTime: partial,
+			LineNumber:         textLine.LineNumber,
+			Line:               line,
+			ErrorMessage:       err.Error(),
+		}
+		p.result.ParseErrors = append(p.result.ParseErrors, &parseError)
+	}
+
+	switch rule.record {
+	case RECORD_INDIVIDUAL:
+		swimmerTime, err := formatSwimmerTime(fields)
+		if err != nil {
+			addError("IndividualTime", err, swimmerTime)
+		}
+		if err == nil || p.options.Mode == MODE_LENIENT {
+			swimmerTime.Uncertain = err != nil
+			swimmerTime.Event = p.event
+			swimmerTime.Source = source
+			swimmerTime.Round = p.round
+			if swimmerTime.Heat == "" {
+				swimmerTime.Heat = p.heat
+			}
+			p.result.Times = append(p.result.Times, swimmerTime)
+		}
+	case RECORD_RELAY:
+		relayTime, err := formatRelayTime(fields)
+		p.relay = nil
+		if err != nil {
+			addError("RelayTime", err, nil)
+		}
+		if err == nil || p.options.Mode == MODE_LENIENT {
+			relayTime.Uncertain = err != nil
+			relayTime.Event = p.event
+			relayTime.Source = source
+			relayTime.Round = p.round
+			if relayTime.Heat == "" {
+				relayTime.Heat = p.heat
+			}
+			p.result.RelayTimes = append(p.result.RelayTimes, relayTime)
+			p.relay = relayTime
+		}
+	case RECORD_RELAY_SWIMMERS:
+		if p.relay == nil {
+			addError("RelaySwimmer", fmt.Errorf("relay swimmers without a relay"), nil)
+			return
+		}
+		for _, swimmerMatch := range rule.pattern.FindAllStringSubmatch(line, -1) {
+			swimmerFields := rule.groups(swimmerMatch)
+			p.relay.Swimmers = append(p.relay.Swimmers, &RelaySwimmer{
+				Place:       swimmerFields["leg"],
+				Name:        swimmerFields["name"],
+				Age:         swimmerFields["age"],
+				YearOfBirth: swimmerFields["yearOfBirth"],
+			})
+		}
+	case RECORD_SPLITS:
+		splits := line
+		if value, ok := fields["splits"]; ok {
+			splits = value
+		}
+		swimmerTime := p.lastSwimmerTime()
+		if swimmerTime == nil {
+			addError("IndividualTime", fmt.Errorf("split times without a swimmer time"), nil)
+			return
+		}
+		swimmerTime.SplitTimes = append(swimmerTime.SplitTimes, getSplitTimes(splits)...)
+	case RECORD_HEAT:
+		p.heat = fields["heat"]
+	case RECORD_ROUND:
+		if round, ok := parseRoundMarker(fields["round"]); ok {
+			p.round = round
+		} else {
+			p.round = strings.ToLower(fields["round"])
+		}
+		p.heat = ""
+	}
+}
+
+// lastSwimmerTime returns the last swimmer time of the open event
+func (p *formatParser) lastSwimmerTime() *SwimmerTime {
+	if len(p.result.Times) == 0 || p.result.Times[len(p.result.Times)-1].Event != p.event {
+		return nil
+	}
+	return p.result.Times[len(p.result.Times)-1]
+}
+
+// formatPlace parses the place group. Results without a place (DQ, DNS) are unranked.
+func formatPlace(value string) (Place, error) {
+	if value == "" {
+		return Place{Unranked: true}, nil
+	}
+	return parsePlace(value)
+}
+
+func formatSwimmerTime(fields map[string]string) (*SwimmerTime, error) {
+	swimmerTime := &SwimmerTime{
+		Name:                fields["name"],
+		Age:                 fields["age"],
+		YearOfBirth:         fields["yearOfBirth"],
+		Grade:               fields["grade"],
+		TeamName:            fields["team"],
+		TeamLSC:             fields["lsc"],
+		Heat:                fields["heat"],
+		Lane:                fields["lane"],
+		SeedTime:            fields["seedTime"],
+		PrelimTime:          fields["prelimTime"],
+		Time:                fields["time"],
+		QualifyingStandards: fields["qualifyingStandards"],
+		Achievements:        fields["achievements"],
+	}
+	var err error
+	swimmerTime.Place, err = formatPlace(fields["place"])
+	if err != nil {
+		return swimmerTime, err
+	}
+	if fields["points"] != "" {
+		err = swimmerTime.setPoints(fields["points"])
+		if err != nil {
+			return swimmerTime, err
+		}
+	}
+	if fields["worldAquaticsPoints"] != "" {
+		swimmerTime.WorldAquaticsPoints, err = strconv.Atoi(fields["worldAquaticsPoints"])
+		if err != nil {
+			return swimmerTime, fmt.Errorf("world aquatics points: %w", err)
+		}
+	}
+	if swimmerTime.Name == "" {
+		return swimmerTime, fmt.Errorf("name is empty")
+	}
+	return swimmerTime, nil
+}
+
+func formatRelayTime(fields map[string]string) (*RelayTime, error) {
+	relayTime := &RelayTime{
+		TeamName:            fields["team"],
+		TeamLSC:             fields["lsc"],
+		TeamNameShort:       fields["teamShort"],
+		RelayEntry:          fields["relay"],
+		Heat:                fields["heat"],
+		SeedTime:            fields["seedTime"],
+		Time:                fields["time"],
+		QualifyingStandards: fields["qualifyingStandards"],
+		Achievements:        fields["achievements"],
+		Swimmers:            []*RelaySwimmer{},
+	}
+	var err error
+	relayTime.Place, err = formatPlace(fields["place"])
+	if err != nil {
+		return relayTime, err
+	}
+	if fields["points"] != "" {
+		err = relayTime.setPoints(fields["points"])
+		if err != nil {
+			return relayTime, err
+		}
+	}
+	if fields["worldAquaticsPoints"] != "" {
+		relayTime.WorldAquaticsPoints, err = strconv.Atoi(fields["worldAquaticsPoints"])
+		if err != nil {
+			return relayTime, fmt.Errorf("world aquatics points: %w", err)
+		}
+	}
+	if relayTime.TeamName == "" {
+		return relayTime, fmt.Errorf("team is empty")
+	}
+	return relayTime, nil
+}
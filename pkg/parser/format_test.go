@@ -0,0 +1,182 @@
+package parser
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePDFTextFormat(t *testing.T) {
+	format, err := LoadFormat("testdata/formats/acme.json")
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	input := "Acme Timing Results - Spring Open\n" +
+		"Event 3 Women 50m Freestyle\n" +
+		"Rank Name YoB Club Time Points\n" +
+		"1. SMITH Jane (2010) Lakeside SC 28.45 512\n" +
+		"2. VAN DER BERG Anna (2011) River Club 29,10 480\n" +
+		"   50m: 14.10  29.10\n" +
+		"DSQ BROWN Kim (2010) Lakeside SC DQ\n" +
+		"3 Lastname, Firstname 28.00\n" +
+		"Event 4 Mixed 4x50m Freestyle Relay\n" +
+		"Rank Club Relay Time\n" +
+		"Heat 2\n" +
+		"1. Lakeside SC A 1:58.20\n" +
+		"   SMITH Jane, BROWN Kim, GREEN Tom, WHITE Sam\n" +
+		"Team Scores\n" +
+		"1. Lakeside SC 120\n"
+	result, err := parsePDFText(bytes.NewBufferString(input), Options{Format: format, OmitSource: true})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(result.Events) != 2 {
+		t.Fatalf("got %d events, expected 2", len(result.Events))
+	}
+	expectedEvent := &Event{Round: "4", Gender: "mixed", Distance: "4x50m", Stroke: "Freestyle", Relay: true, QualifyingTimes: map[string]string{}}
+	if diff := cmp.Diff(expectedEvent, result.Events[1]); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+
+	type swim struct {
+		Name, YearOfBirth, TeamName, Time string
+		Place                             Place
+		Points                            float64
+		SplitTimes                        []string
+	}
+	got := []swim{}
+	for _, swimmerTime := range result.Times {
+		got = append(got, swim{swimmerTime.Name, swimmerTime.YearOfBirth, swimmerTime.TeamName, swimmerTime.Time, swimmerTime.Place, swimmerTime.Points, swimmerTime.SplitTimes})
+	}
+	expected := []swim{
+		{"Smith, Jane", "2010", "Lakeside SC", "28.45", Place{Value: 1}, 512, nil},
+		{"Van Der Berg, Anna", "2011", "River Club", "29.10", Place{Value: 2}, 480, []string{"14.10", "29.10"}},
+		{"Brown, Kim", "2010", "Lakeside SC", "DQ", Place{Unranked: true}, 0, nil},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(result.RelayTimes) != 1 {
+		t.Fatalf("got %d relay times, expected 1", len(result.RelayTimes))
+	}
+	relayTime := result.RelayTimes[0]
+	if relayTime.TeamName != "Lakeside SC" || relayTime.RelayEntry != "A" || relayTime.Time != "1:58.20" || relayTime.Heat != "2" || relayTime.Event != result.Events[1] {
+		t.Fatalf("unexpected relay time: %+v", relayTime)
+	}
+	names := []string{}
+	for _, relaySwimmer := range relayTime.Swimmers {
+		names = append(names, relaySwimmer.Name)
+	}
+	if diff := cmp.Diff([]string{"Smith, Jane", "Brown, Kim", "Green, Tom", "White, Sam"}, names); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+
+	// the line without a rule is unparsed, the team scores after the section end are not
+	if len(result.UnparsedLines) != 1 || result.UnparsedLines[0].Line != "3 Lastname, Firstname 28.00" {
+		t.Fatalf("got unparsed lines %+v, expected the line without a rule", result.UnparsedLines)
+	}
+	if result.Coverage.Lines.Seen != 8 || result.Coverage.Lines.Recognized != 7 {
+		t.Fatalf("got coverage %+v, expected 7 of 8 lines", result.Coverage.Lines)
+	}
+}
+
+func TestParseLinesFormatRecover(t *testing.T) {
+	format, err := LoadFormat("testdata/formats/acme.json")
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	lines := []*textLine{
+		{Text: "Event 3 Women 50m Freestyle", LineNumber: 0},
+		{Text: "Rank Name YoB Club Time Points", LineNumber: 1},
+		{Text: "1. SMITH Jane (2010) Lakeside SC 28.45 512", LineNumber: 2},
+		{Text: "2. VAN DER BERG Anna (2011) River Club 29,10 480", LineNumber: 3},
+	}
+	result, _ := parseLines(lines, Options{Format: format, Tracer: &panickingTracer{lineNumber: 2}})
+	if len(result.ParseErrors) != 1 {
+		t.Fatalf("got %d parse errors, expected 1", len(result.ParseErrors))
+	}
+	parseError := result.ParseErrors[0]
+	if parseError.Type != "Panic" || parseError.LineNumber != 2 || parseError.Stack == "" {
+		t.Fatalf("unexpected parse error: %+v", parseError)
+	}
+	// the line after the panic is parsed
+	if len(result.Times) != 1 || result.Times[0].Name != "Van Der Berg, Anna" {
+		t.Fatalf("got times %+v, expected the swimmer of the line after the panic", result.Times)
+	}
+}
+
+func TestLoadFormat(t *testing.T) {
+	tests := []struct {
+		format      string
+		expectedErr string
+	}{
+		{`{"name": "x", "event": {"pattern": "^Event (?P<number>\\d+)"}, "lines": [{"record": "individual", "pattern": "^(?P<name>\\S+) (?P<time>\\S+)$"}]}`, ""},
+		{`{"event": {"pattern": "^Event"}}`, "name: the format needs a name"},
+		{`{"name": "x", "sectionEnd": ["("], "event": {"pattern": "^Event"}}`, "sectionEnd[0]: error parsing regexp: missing closing ): `(`"},
+		{`{"name": "x", "event": {"pattern": "^Event \\d+"}, "lines": []}`, "event: pattern: missing group 'number'"},
+		{`{"name": "x", "event": {"pattern": "^Event (?P<number>\\d+)"}, "lines": [{"record": "heat", "pattern": "^Heat (?P<heat>\\d+)"}, {"name": "swimmer", "record": "individual", "pattern": "^(?P<nmae>\\S+) (?P<time>\\S+)$"}]}`,
+			"lines[1] (swimmer): pattern: unknown group 'nmae' (expected name, time, place, age, yearOfBirth, grade, team, lsc, heat, lane, seedTime, prelimTime, points, worldAquaticsPoints, qualifyingStandards, achievements)"},
+		{`{"name": "x", "event": {"pattern": "^Event (?P<number>\\d+)"}, "lines": [{"record": "split", "pattern": "^\\d"}]}`,
+			"lines[0]: unknown record 'split' (expected individual, relay, relaySwimmers, splits, heat, round or ignore)"},
+		{`{"name": "x", "event": {"pattern": "^Event (?P<number>\\d+)"}, "lines": [{"record": "individual", "pattern": "^(?P<name>\\S+) (?P<time>\\S+)$", "process": {"name": ["trim", "surname"]}}]}`,
+			"lines[0]: process.name[1]: unknown post-processor 'surname' (expected ageGroup, collapseSpaces, decimalComma, dropParentheses, gender, lower, stroke, surnameFirst, title, trim, upper or replace:old:new)"},
+		{`{"name": "x", "event": {"pattern": "^Event (?P<number>\\d+)", "process": {"gender": ["gender"]}}, "lines": [{"record": "ignore", "pattern": "^$"}]}`,
+			"event: process.gender: the pattern doesn't have the group"},
+		{`{"name": "x", "event": {"pattern": "^Event (?P<number>\\d+)"}, "lines": [{"record": "ignore", "pattern": "^$", "patern": "x"}]}`,
+			`couldn't parse format definition: json: unknown field "patern"`},
+	}
+	for _, tt := range tests {
+		filename := filepath.Join(t.TempDir(), "format.json")
+		if err := os.WriteFile(filename, []byte(tt.format), 0644); err != nil {
+			t.Fatalf("couldn't write format: %s", err)
+		}
+		_, err := LoadFormat(filename)
+		if tt.expectedErr == "" && err != nil {
+			t.Fatalf("got error: %s", err)
+		}
+		if tt.expectedErr != "" && (err == nil || err.Error() != tt.expectedErr) {
+			t.Fatalf("got error %v, expected '%s'", err, tt.expectedErr)
+		}
+	}
+}
+
+func TestSurnameFirst(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"SMITH Jane", "Smith, Jane"},
+		{"VAN DER BERG Anna Maria", "Van Der Berg, Anna Maria"},
+		{"O'NEILL-JONES Kate", "O'Neill-Jones, Kate"},
+		{"Lastname, Firstname", "Lastname, Firstname"},
+		{"SMITH", "SMITH"},
+	}
+	for _, tt := range tests {
+		if got := surnameFirst(tt.name); got != tt.expected {
+			t.Fatalf("%s: got '%s', expected '%s'", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestRelayDistance(t *testing.T) {
+	tests := []struct {
+		legs, legDistance string
+		expected          string
+		expectedErr       string
+	}{
+		{"4", "100m", "400m", ""},
+		{"4", "50 Yard", "200 Yard", ""},
+		{"x", "100m", "100m", "relay legs 'x' is not a number"},
+		{"4", "m", "m", "relay leg distance 'm' doesn't start with a number"},
+	}
+	for _, tt := range tests {
+		got, err := relayDistance(tt.legs, tt.legDistance)
+		if got != tt.expected || (err == nil && tt.expectedErr != "") || (err != nil && err.Error() != tt.expectedErr) {
+			t.Fatalf("%s x %s: got '%s' (error %v), expected '%s' (error '%s')", tt.legs, tt.legDistance, got, err, tt.expected, tt.expectedErr)
+		}
+	}
+}
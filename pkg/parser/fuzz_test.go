@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"testing"
+)
+
+// the fuzz targets only check that no line can panic: go test -fuzz=FuzzProcessLine
+
+func fileTypeOf(type2 bool) string {
+	if type2 {
+		return FILETYPE_TYPE2
+	}
+	return FILETYPE_TYPE1
+}
+
+func FuzzProcessLine(f *testing.F) {
+	f.Add("1 Lastname, Firstname  14 Lynchburg YMCA 2:14.96 x2:16.72 AG 9", "Name Age Team Seed Time Finals Time Points", false)
+	f.Add("1 Lastname, Firstname  14 Lynchburg YMCA 1:00.00 59.00 58.50 20", "Name Age Team Seed Prelims Finals Points", false)
+	f.Add("1 Lastname, Firstname SR Lynchburg High School 1:00.00 58.50 20", "Name Yr School Seed Time Finals Time Points", false)
+	f.Add("--- Lastname, Firstname  10 Lynchburg YMCA 35.00 DQ", "Name Age Team Seed Time Finals Time", false)
+	f.Add("1 Lastname, Firstname 6 PFP 18.14 x18.39 9 INV", "", true)
+	f.Fuzz(func(t *testing.T, line string, header string, type2 bool) {
+		processLine(line, fileTypeOf(type2), parseColumnSchema(header))
+	})
+}
+
+func FuzzProcessRelayLine(f *testing.F) {
+	f.Add("1 Nitro Swimming-ST     A 9:02.07 8:43.46 TAGS 40", false)
+	f.Add("1 Nitro Swimming-ST     A 10:43.41 Y 9:29.11 17.50", false)
+	f.Add("1 SwimTeam A SWT 2:05.49 1:26.68 9 INV", true)
+	f.Fuzz(func(t *testing.T, line string, type2 bool) {
+		processRelayLine(line, fileTypeOf(type2))
+	})
+}
+
+func FuzzProcessRelaySwimmersLine(f *testing.F) {
+	f.Add("1) Lastname, Firstname 14 2) Gunn, Pepper 13 3) Peeters, Hanne 14 4) Sitter, Gianna 14", false)
+	f.Add("1) Lastname, Firstname (6) 2) Raley, Colton (6)", true)
+	f.Add("1) — 2) Raley, Colton (6)", true)
+	f.Fuzz(func(t *testing.T, line string, type2 bool) {
+		processRelaySwimmersLine(line, fileTypeOf(type2))
+	})
+}
+
+func FuzzProcessEvent(f *testing.F) {
+	f.Add("Event 57  Girls 10 & Under 50 LC Meter Butterfly", false)
+	f.Add("(Event 12  Girls 10 & Under 50 Yard Freestyle)", false)
+	f.Add("#1 Mixed 6 & Under 100yd Freestyle Relay", true)
+	f.Fuzz(func(t *testing.T, line string, type2 bool) {
+		processEvent(line, fileTypeOf(type2))
+	})
+}
+
+func TestParseLinesRelaySwimmersWithoutRelay(t *testing.T) {
+	lines := []*textLine{
+		{Text: "Event 1  Girls 10 & Under 200 Yard Freestyle Relay"},
+		{Text: "Team  Relay Seed Time Finals Time Points"},
+		{Text: "1) Lastname, Firstname 10 2) Lastname, Second 9 3) Lastname, Third 10 4) Lastname, Fourth 9"},
+		{Text: "1 Lynchburg YMCA     A 2:30.00 2:25.10 18"},
+	}
+	result, _ := parseLines(lines, Options{})
+	if len(result.RelayTimes) != 1 || len(result.ParseErrors) != 1 {
+		t.Fatalf("got %d relay times and %d parse errors, expected 1 and 1", len(result.RelayTimes), len(result.ParseErrors))
+	}
+	if result.ParseErrors[0].ErrorMessage != "relay swimmers without a relay" {
+		t.Fatalf("got error '%s', expected relay swimmers without a relay", result.ParseErrors[0].ErrorMessage)
+	}
+}
+
+// panickingTracer panics on the first event of a line, once
+type panickingTracer struct {
+	lineNumber int
+	panicked   bool
+}
+
+func (p *panickingTracer) Trace(event TraceEvent) {
+	if event.LineNumber == p.lineNumber && !p.panicked {
+		p.panicked = true
+		panic("tracer panic")
+	}
+}
+
+func TestParseLinesRecover(t *testing.T) {
+	lines := []*textLine{
+		{Text: "Event 1  Girls 10 & Under 50 Yard Freestyle", LineNumber: 0},
+		{Text: "Name Age Team Seed Time Finals Time Points", LineNumber: 1},
+		{Text: "1 Lastname, Firstname  10 Lynchburg YMCA 33.10 32.54 9", LineNumber: 2},
+		{Text: "2 Lastname, Second  9 Heritage Swim 34.00 33.80 7", LineNumber: 3},
+	}
+	result, _ := parseLines(lines, Options{Tracer: &panickingTracer{lineNumber: 2}})
+	if len(result.ParseErrors) != 1 {
+		t.Fatalf("got %d parse errors, expected 1", len(result.ParseErrors))
+	}
+	parseError := result.ParseErrors[0]
+	if parseError.Type != "Panic" || parseError.LineNumber != 2 || parseError.ErrorMessage != "panic: tracer panic" || parseError.Stack == "" {
+		t.Fatalf("unexpected parse error: %+v", parseError)
+	}
+	// the line after the panic is parsed
+	if len(result.Times) != 1 || result.Times[0].Name != "Lastname, Second" {
+		t.Fatalf("got times %+v, expected the swimmer of the line after the panic", result.Times)
+	}
+}
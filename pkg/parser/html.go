@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"html"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var htmlPreRegex = regexp.MustCompile(`(?is)<pre[^>]*>(.*?)</pre>`)
+var htmlBreakRegex = regexp.MustCompile(`(?i)<br\s*/?>`)
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// ParseHTML parses the results that Meet Manager publishes as .htm files
+func ParseHTML(filePath string) (Result, error) {
+	return ParseHTMLWithOptions(filePath, Options{})
+}
+
+// ParseHTMLWithOptions parses the .htm results of Meet Manager with the given options
+func ParseHTMLWithOptions(filePath string, options Options) (Result, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return Result{}, err
+	}
+	defer file.Close()
+	return parseHTML(file, options)
+}
+
+func parseHTML(reader io.Reader, options Options) (Result, error) {
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return Result{}, err
+	}
+	lines, err := layoutLines(strings.NewReader(htmlText(string(body))))
+	result, parseErr := parseLines(lines, options)
+	if err != nil {
+		return result, err
+	}
+	return result, parseErr
+}
+
+// htmlText returns the text of the <pre> blocks, without markup. Every block is a page: the blocks are
+// separated by a page break, which pdftotext -layout output also uses.
+// block: <pre><b>Event 1  Girls 10 &amp; Under 50 Yard Freestyle</b>
+func htmlText(body string) string {
+	blocks := []string{}
+	for _, match := range htmlPreRegex.FindAllStringSubmatch(body, -1) {
+		text := htmlBreakRegex.ReplaceAllString(match[1], "\n")
+		text = htmlTagRegex.ReplaceAllString(text, "")
+		text = html.UnescapeString(text)
+		text = strings.ReplaceAll(text, "\u00a0", " ") // &nbsp;
+		text = strings.ReplaceAll(text, "\r\n", "\n")
+		blocks = append(blocks, strings.Trim(text, "\n"))
+	}
+	return strings.Join(blocks, "\n\f")
+}
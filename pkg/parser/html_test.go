@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseHTML(t *testing.T) {
+	input := `<html><head><title>Results</title></head>
+<body>
+<pre>
+<b>Lynchburg YMCA Summer Invitational - 6/1/2025</b>
+                                Results
+
+<b>Event 1  Girls 10 &amp; Under 50 Yard Freestyle</b>
+===============================================================================
+    Name                   Age Team                Seed Time  Finals Time  Points
+===============================================================================
+  1 Lastname, Firstname      10 Lynchburg YMCA          33.10        32.54       9
+  2 O&#39;Lastname, Second       9 Heritage Swim           34.00        33.80       7
+--- Lastname, Third          10 Lynchburg YMCA          35.00           DQ
+</pre>
+<p style="page-break-before: always"></p>
+<pre>
+<b>Event 2  Girls 10 &amp; Under 200 Yard Freestyle Relay</b>
+===============================================================================
+    Team                      Relay                Seed Time  Finals Time  Points
+===============================================================================
+  1 Lynchburg YMCA              A                    2:30.00      2:25.10      18
+    1) Lastname, Firstname 10      2) Lastname, Second 9<br>    3) Lastname, Third 10          4) Lastname, Fourth 9
+</pre>
+</body></html>
+`
+	res, err := parseHTML(bytes.NewBufferString(input), Options{})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	for _, parseError := range res.ParseErrors {
+		t.Fatalf("parse error: %+v", parseError)
+	}
+	if len(res.Events) != 2 {
+		t.Fatalf("got %d events, expected 2", len(res.Events))
+	}
+	if res.Events[0].AgeGroup != "10 & under" {
+		t.Fatalf("got age group '%s', expected '10 & under'", res.Events[0].AgeGroup)
+	}
+	if len(res.Times) != 3 {
+		t.Fatalf("got %d swimmer times, expected 3", len(res.Times))
+	}
+	if res.Times[1].Name != "O'Lastname, Second" {
+		t.Fatalf("got name '%s', expected \"O'Lastname, Second\"", res.Times[1].Name)
+	}
+	if len(res.RelayTimes) != 1 || len(res.RelayTimes[0].Swimmers) != 4 {
+		t.Fatalf("expected 1 relay with 4 swimmers, got %+v", res.RelayTimes)
+	}
+}
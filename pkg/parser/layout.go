@@ -0,0 +1,318 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var layoutSpacesRegex = regexp.MustCompile(` {2,}`)
+var layoutEventRegex = regexp.MustCompile(`^(\(?(?:Event|event)\s+\S+)\s+(.*)$`)
+
+// a result line starts with the place and a name without digits
+var layoutResultRegex = regexp.MustCompile(`^(?:[*xX]?\d+\*?|-{2,3})\s+[^\d\s,][^\d,]*,`)
+var layoutRelayLetterRegex = regexp.MustCompile(` {2,}([A-Z]) `)
+
+// gutter between two columns of a page: the x positions [Start, End) are empty on (almost) every line
+type layoutGutter struct {
+	Start int
+	End   int
+}
+
+// ParseLayoutText parses the output of poppler's pdftotext -layout
+func ParseLayoutText(filePath string) (Result, error) {
+	return ParseLayoutTextWithOptions(filePath, Options{})
+}
+
+// ParseLayoutTextWithOptions parses the output of poppler's pdftotext -layout with the given options
+func ParseLayoutTextWithOptions(filePath string, options Options) (Result, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return Result{}, err
+	}
+	defer file.Close()
+	return parseLayoutText(file, options)
+}
+
+func parseLayoutText(reader io.Reader, options Options) (Result, error) {
+	lines, err := layoutLines(reader)
+	result, parseErr := parseLines(lines, options)
+	if err != nil {
+		return result, err
+	}
+	return result, parseErr
+}
+
+// layoutLines converts pdftotext -layout text to the lines of the column extractor: the columns of a page
+// are read one after the other, and the spacing is normalized to the spacing of the extractor.
+// Every line keeps its page, column and line number in the pdftotext output.
+func layoutLines(reader io.Reader) ([]*textLine, error) {
+	pages := [][]*textLine{{}}
+	scanner := bufio.NewScanner(reader)
+	for i := 0; scanner.Scan(); i++ {
+		line := strings.ReplaceAll(scanner.Text(), "\t", " ")
+		// pdftotext starts every next page with a form feed
+		for strings.Contains(line, "\f") {
+			index := strings.Index(line, "\f")
+			if before := line[:index]; strings.TrimSpace(before) != "" {
+				pages[len(pages)-1] = append(pages[len(pages)-1], &textLine{Text: before, Page: len(pages), LineNumber: i})
+			}
+			pages = append(pages, []*textLine{})
+			line = line[index+1:]
+		}
+		pages[len(pages)-1] = append(pages[len(pages)-1], &textLine{Text: line, Page: len(pages), LineNumber: i})
+	}
+
+	// the spacing depends on the layout. When no layout fits, parseLines reports it.
+	fileType := FILETYPE_TYPE1
+	all := []*textLine{}
+	for _, page := range pages {
+		all = append(all, page...)
+	}
+	if detection, err := detectFormat(all); err == nil {
+		fileType = detection.Winner.fileType
+	}
+
+	lines := []*textLine{}
+	if fileType == FILETYPE_TYPE2 {
+		lines = append(lines, &textLine{Text: "FileType: " + FILETYPE_TYPE2, LineNumber: -1})
+	}
+	for _, page := range pages {
+		for _, line := range layoutPageLines(page) {
+			line.Text = normalizeLayoutLine(line.Text, fileType)
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// layoutPageLines returns the lines of a page, column by column. The page header and the lines that cross
+// a gutter after the columns started (the page footer) are kept in front of or after the columns.
+func layoutPageLines(page []*textLine) []*textLine {
+	texts := make([]string, len(page))
+	for k, line := range page {
+		texts[k] = line.Text
+	}
+	gutters := layoutGutters(texts)
+	if len(gutters) == 0 {
+		return page
+	}
+	header := []*textLine{}
+	footer := []*textLine{}
+	columns := make([][]*textLine, len(gutters)+1)
+	columnsStarted := false
+	for _, line := range page {
+		if strings.TrimSpace(line.Text) == "" {
+			if columnsStarted {
+				for k := range columns {
+					columns[k] = append(columns[k], &textLine{Page: line.Page, Column: k + 1, LineNumber: line.LineNumber})
+				}
+			}
+			continue
+		}
+		segments := layoutSplit([]rune(line.Text), gutters)
+		// the page header ends at the first line with results
+		if !columnsStarted && !layoutHasResults(segments) {
+			header = append(header, line)
+			continue
+		}
+		if segments == nil {
+			footer = append(footer, line)
+			continue
+		}
+		// a full width line in between the columns belongs to the first column
+		columns[0] = append(columns[0], footer...)
+		footer = []*textLine{}
+		columnsStarted = true
+		for k, segment := range segments {
+			if segment != "" {
+				columns[k] = append(columns[k], &textLine{Text: segment, Page: line.Page, Column: k + 1, LineNumber: line.LineNumber})
+			}
+		}
+	}
+	lines := header
+	for k, column := range columns {
+		lines = append(lines, column...)
+		// the extractor ends every column with an empty line
+		lines = append(lines, &textLine{Page: page[0].Page, Column: k + 1, LineNumber: page[len(page)-1].LineNumber})
+	}
+	return append(lines, footer...)
+}
+
+// layoutSplit splits a line in its columns, at the spacing in every gutter.
+// It returns nil when text crosses a gutter without spacing.
+func layoutSplit(runes []rune, gutters []layoutGutter) []string {
+	segments := []string{}
+	start := 0
+	for _, gutter := range gutters {
+		if gutter.Start >= len(runes) {
+			break
+		}
+		// the first spacing in the gutter: text can start a bit inside the gutter
+		x := gutter.Start
+		for x < gutter.End && x < len(runes) && runes[x] != ' ' {
+			x++
+		}
+		if x == gutter.End {
+			return nil
+		}
+		spaceStart, spaceEnd := x, x
+		for spaceStart > start && runes[spaceStart-1] == ' ' {
+			spaceStart--
+		}
+		for spaceEnd < len(runes) && runes[spaceEnd] == ' ' {
+			spaceEnd++
+		}
+		if spaceEnd-spaceStart < 2 && spaceEnd < len(runes) {
+			return nil
+		}
+		segments = append(segments, strings.TrimSpace(string(runes[start:spaceStart])))
+		start = spaceEnd
+	}
+	if start < len(runes) {
+		segments = append(segments, strings.TrimSpace(string(runes[start:])))
+	}
+	for len(segments) < len(gutters)+1 {
+		segments = append(segments, "")
+	}
+	return segments
+}
+
+// layoutHasResults returns true when one of the columns of the line is an event or a result
+func layoutHasResults(segments []string) bool {
+	for _, segment := range segments {
+		if isEvent(segment, FILETYPE_TYPE1) || strings.HasPrefix(segment, "(Event") || isvalidTime.MatchString(segment) || startsWithPlace(segment) || isRelaySwimmerLine(segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// layoutGutters finds the gutters between the columns of a page. A page has 2 or 3 columns of about the same width:
+// the gutters have to be close to the half or the thirds of the page, and a column has to start with results
+// (an event or a result line) to the right of the gutter.
+func layoutGutters(page []string) []layoutGutter {
+	width := 0
+	contentLines := 0
+	for _, line := range page {
+		width = max(width, len([]rune(line)))
+		if strings.TrimSpace(line) != "" {
+			contentLines++
+		}
+	}
+	if contentLines < 4 {
+		return nil
+	}
+	occupied := make([]int, width)
+	for _, line := range page {
+		for x, r := range []rune(line) {
+			if r != ' ' {
+				occupied[x]++
+			}
+		}
+	}
+	// the page header and footer can cross the gutter
+	maxCrossing := max(2, contentLines/10)
+	candidates := []layoutGutter{}
+	for x := 1; x < width; x++ {
+		if occupied[x] > maxCrossing {
+			continue
+		}
+		start := x
+		for x < width && occupied[x] <= maxCrossing {
+			x++
+		}
+		if x < width && x-start >= 2 {
+			candidates = append(candidates, layoutGutter{Start: start, End: x})
+		}
+	}
+
+	for _, columns := range []int{3, 2} {
+		gutters := []layoutGutter{}
+		tolerance := width / 10
+		for k := 1; k < columns; k++ {
+			target := k * width / columns
+			// the widest gutter close to the target
+			var gutter *layoutGutter
+			for _, candidate := range candidates {
+				if candidate.Start <= target+tolerance && candidate.End >= target-tolerance {
+					if gutter == nil || candidate.End-candidate.Start > gutter.End-gutter.Start {
+						gutter = &candidate
+					}
+				}
+			}
+			if gutter != nil && (len(gutters) == 0 || gutter.Start >= gutters[len(gutters)-1].End) {
+				gutters = append(gutters, *gutter)
+			}
+		}
+		if len(gutters) == columns-1 && layoutColumnsStart(page, gutters) {
+			return gutters
+		}
+	}
+	return nil
+}
+
+// layoutColumnsStart returns true when every column right of a gutter has a line that starts an event or a result
+func layoutColumnsStart(page []string, gutters []layoutGutter) bool {
+	for k, gutter := range gutters {
+		found := false
+		for _, line := range page {
+			runes := []rune(line)
+			if len(runes) <= gutter.End {
+				continue
+			}
+			end := len(runes)
+			if k+1 < len(gutters) {
+				end = min(gutters[k+1].Start, len(runes))
+			}
+			segment := strings.TrimSpace(string(runes[gutter.End:end]))
+			if isEvent(segment, FILETYPE_TYPE1) || layoutResultRegex.MatchString(segment) || isRelaySwimmerLine(segment) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeLayoutLine replaces the alignment spacing of pdftotext with the spacing of the extractor, which the
+// line parsers use to split the fields: two spaces after the event number and the swimmer name,
+// five spaces in front of the relay letter, single spaces between the other fields.
+// line: 1 Lastname, Firstname        14 Lynchburg YMCA        2:14.96     2:16.72  AG    9
+func normalizeLayoutLine(line string, fileType string) string {
+	line = strings.TrimSpace(line)
+	if fileType == FILETYPE_TYPE2 {
+		return collapseSpaces(line)
+	}
+	switch {
+	case layoutEventRegex.MatchString(line):
+		// line: Event 1  Girls 13-14 200 Yard IM
+		match := layoutEventRegex.FindStringSubmatch(line)
+		return collapseSpaces(match[1]) + "  " + collapseSpaces(match[2])
+	case isvalidTime.MatchString(line):
+		// line: 1 Lastname, Firstname  14 Lynchburg YMCA 2:14.96 2:16.72 AG 9
+		comma := strings.Index(line, ",")
+		if gap := layoutSpacesRegex.FindStringIndex(line[comma:]); gap != nil {
+			return collapseSpaces(line[:comma+gap[0]]) + "  " + collapseSpaces(line[comma+gap[1]:])
+		}
+		return line
+	case startsWithPlace(line) && !isRelaySwimmerLine(line):
+		// line: 1 Nitro Swimming-ST     A 9:02.07 8:43.46 TAGS 40
+		if letter := layoutRelayLetterRegex.FindStringSubmatchIndex(line); letter != nil {
+			return collapseSpaces(line[:letter[0]]) + "     " + collapseSpaces(line[letter[2]:])
+		}
+		return collapseSpaces(line)
+	case isRelaySwimmerLine(line) || splitTimesRegex.MatchString(collapseSpaces(line)):
+		return collapseSpaces(line)
+	}
+	return layoutSpacesRegex.ReplaceAllString(line, "  ")
+}
+
+func collapseSpaces(s string) string {
+	return layoutSpacesRegex.ReplaceAllString(s, " ")
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseLayoutText(t *testing.T) {
+	tests := []struct {
+		file           string
+		expectedEvents int
+		expectedTimes  int
+		expectedRelays int
+	}{
+		{"testdata/layout/hytek-two-columns.txt", 5, 10, 2},
+		{"testdata/layout/hytek-three-columns.txt", 3, 7, 0},
+		{"testdata/layout/hytek-single-column.txt", 1, 4, 0},
+		{"testdata/layout/swimtopia-two-columns.txt", 2, 5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			out, err := ParseLayoutText(tt.file)
+			if err != nil {
+				t.Fatalf("error: %s", err)
+			}
+			for _, parseError := range out.ParseErrors {
+				t.Fatalf("parse error: %+v", parseError)
+			}
+			if len(out.Events) != tt.expectedEvents {
+				t.Fatalf("got %d events, expected %d", len(out.Events), tt.expectedEvents)
+			}
+			if len(out.Times) != tt.expectedTimes {
+				t.Fatalf("got %d swimmer times, expected %d", len(out.Times), tt.expectedTimes)
+			}
+			if len(out.RelayTimes) != tt.expectedRelays {
+				t.Fatalf("got %d relay times, expected %d", len(out.RelayTimes), tt.expectedRelays)
+			}
+			for _, swimmerTime := range out.Times {
+				if swimmerTime.Event == nil || swimmerTime.Name == "" || swimmerTime.TeamName == "" || swimmerTime.Time == "" {
+					t.Fatalf("incomplete swimmer time: %+v", swimmerTime)
+				}
+			}
+			for _, relayTime := range out.RelayTimes {
+				if len(relayTime.Swimmers) != 4 {
+					t.Fatalf("got %d relay swimmers, expected 4: %+v", len(relayTime.Swimmers), relayTime)
+				}
+			}
+		})
+	}
+}
+
+func TestLayoutLines(t *testing.T) {
+	input := "Meet Results                                                               Page 1\n" +
+		"Event 1  Girls 10 & Under 50 Yard Freestyle            Event 2  Boys 10 & Under 50 Yard Freestyle\n" +
+		"  1 Lastname, Firstname   10 Lynchburg YMCA   33.10      1 Lastname, Boy     10 Heritage Swim   30.10\n" +
+		"  2 Lastname, Second       9 Heritage Swim    34.00      2 Lastname, Kid     10 Heritage Swim   31.10\n" +
+		"  3 Lastname, Third       10 Heritage Swim    35.00\n"
+	lines, err := layoutLines(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	expected := []string{
+		"Meet Results  Page 1",
+		"Event 1  Girls 10 & Under 50 Yard Freestyle",
+		"1 Lastname, Firstname  10 Lynchburg YMCA 33.10",
+		"2 Lastname, Second  9 Heritage Swim 34.00",
+		"3 Lastname, Third  10 Heritage Swim 35.00",
+		"",
+		"Event 2  Boys 10 & Under 50 Yard Freestyle",
+		"1 Lastname, Boy  10 Heritage Swim 30.10",
+		"2 Lastname, Kid  10 Heritage Swim 31.10",
+		"",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("got %d lines, expected %d", len(lines), len(expected))
+	}
+	for k := range expected {
+		if lines[k].Text != expected[k] {
+			t.Fatalf("line %d: got '%s', expected '%s'", k, lines[k].Text, expected[k])
+		}
+	}
+	// line: 2 Lastname, Kid  10 Heritage Swim 31.10
+	if line := lines[8]; line.Page != 1 || line.Column != 2 || line.LineNumber != 3 {
+		t.Fatalf("got page %d, column %d, line number %d, expected page 1, column 2, line number 3", line.Page, line.Column, line.LineNumber)
+	}
+}
+
+func TestNormalizeLayoutLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{"Event 12   Girls 13-14 200 Yard IM", "Event 12  Girls 13-14 200 Yard IM"},
+		{"  1 Lastname, Firstname      14 Lynchburg YMCA     2:14.96     2:16.72  AG    9", "1 Lastname, Firstname  14 Lynchburg YMCA 2:14.96 2:16.72 AG 9"},
+		{"  1 Nitro Swimming-ST          A      9:02.07     8:43.46  TAGS   40", "1 Nitro Swimming-ST     A 9:02.07 8:43.46 TAGS 40"},
+		{"    1) Lastname, Firstname 14      2) Gunn, Pepper 13", "1) Lastname, Firstname 14 2) Gunn, Pepper 13"},
+		{"     28.03       59.10", "28.03 59.10"},
+		{"   Team                   Relay           Seed Time", "Team  Relay  Seed Time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if got := normalizeLayoutLine(tt.line, FILETYPE_TYPE1); got != tt.expected {
+				t.Fatalf("got '%s', expected '%s'", got, tt.expected)
+			}
+		})
+	}
+}
@@ -2,167 +2,614 @@ package parser
 
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"os"
 	"regexp"
+	"runtime/debug"
+	"strconv"
 	"strings"
 	"unicode"
 )
 
-var isvalidTime = regexp.MustCompile(`^(?:\d+|---)\s+(.+?),\s+(.+)`)
+const (
+	SECTION_INDIVIDUAL = "individual"
+	SECTION_RELAY      = "relay"
+)
+
+var isvalidTime = regexp.MustCompile(`^(?:[*xX]?\d+\*?|-{2,3})\s+(.+?),\s+(.+)`)
+var pageNumberRegex = regexp.MustCompile(`Page (\d+)$`)
+
+const (
+	// records with a parse error are left out of the result: individual times, relay times, relay swimmers
+	// and events alike (default)
+	MODE_DEFAULT = ""
+	// records with a parse error are kept with what could be parsed, flagged as uncertain
+	MODE_LENIENT = "lenient"
+	// the parse fails at the first error or warning
+	MODE_STRICT = "strict"
+)
+
+// Options changes how the results are parsed
+type Options struct {
+	// what happens with records that have a parse error: MODE_DEFAULT, MODE_LENIENT or MODE_STRICT
+	Mode string
+	// don't record the source (page, column and line) of the events, times and relay times
+	OmitSource bool
+	// receives the diagnostics of every line: the rules that matched, the fields and the rejected lines
+	Tracer Tracer
+	// parse the lines with the rules of a format definition instead of the built-in formats
+	Format *Format
+}
 
 func ParsePDFText(filePath string) (Result, error) {
+	return ParsePDFTextWithOptions(filePath, Options{})
+}
+
+// ParsePDFTextWithOptions parses the text file of the extractor with the given options
+func ParsePDFTextWithOptions(filePath string, options Options) (Result, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return Result{}, err
 	}
 	defer file.Close()
-	return parsePDFText(file)
+	return parsePDFText(file, options)
 }
-func parsePDFText(reader io.Reader) (Result, error) {
-	var err error
-	result := Result{
-		Times:       []*SwimmerTime{},
-		RelayTimes:  []*RelayTime{},
-		Events:      []*Event{},
-		ParseErrors: []*ParseError{},
-	}
-	fileType := ""
-	pageRegex := regexp.MustCompile(`Page \d+$`)
 
+func parsePDFText(reader io.Reader, options Options) (Result, error) {
+	lines := []*textLine{}
 	scanner := bufio.NewScanner(reader)
-	processIndividual := false
-	processRelay := false
-	var event *Event
-
 	for i := 0; scanner.Scan(); i++ {
-		line := scanner.Text()
-		if i == 0 {
-			if strings.TrimSpace(line) == "FileType: SwimTopia Meet Maestro" {
-				fileType = "SwimTopia Meet Maestro"
+		lines = append(lines, &textLine{Text: scanner.Text(), LineNumber: i})
+	}
+	result, parseErr := parseLines(lines, options)
+	if err := scanner.Err(); err != nil {
+		return result, err
+	}
+	return result, parseErr
+}
+
+// lineParser holds the state of the parser while it reads the lines of a document
+type lineParser struct {
+	options  Options
+	result   Result
+	fileType string
+	// page of the text lines without a page: the page header ends with the page number
+	page              int
+	processIndividual bool
+	processRelay      bool
+	event             *Event
+	round             string
+	heat              string
+	schema            *columnSchema
+	wordColumns       []*wordColumn
+	// teams of the document, from the first pass. Nil in the first pass
+	vocabulary *teamVocabulary
+	// relay of the relay swimmer lines, nil when the relay line was left out
+	relay *RelayTime
+	// section of the open event, suspended by a page or column break
+	suspended string
+}
+
+// parseLines parses the lines of a document in the detected format, in two passes: the first pass collects the teams of the document,
+// the second pass uses the teams to split the name and the team of the lines the spacing can't split.
+// With the format definition of the options, the lines are parsed with the rules of the format instead.
+// In strict mode, the first error or warning is returned with the result up to the line of the error.
+func parseLines(lines []*textLine, options Options) (Result, error) {
+	if options.Format != nil {
+		return options.Format.parseLines(lines, options)
+	}
+	detection, err := detectFormat(lines)
+	var format *Format
+	if err == nil && detection.Winner.format != nil {
+		format, err = detection.Winner.format()
+	}
+	if err != nil {
+		return Result{
+			Times:         []*SwimmerTime{},
+			RelayTimes:    []*RelayTime{},
+			Events:        []*Event{},
+			ParseErrors:   []*ParseError{},
+			UnparsedLines: []*UnparsedLine{},
+			Detection:     detection,
+		}, err
+	}
+	if format != nil {
+		result, err := format.parseLines(lines, options)
+		result.Detection = detection
+		return result, err
+	}
+	fileType := detection.Winner.fileType
+	first, _ := parseLinesPass(lines, Options{OmitSource: true}, fileType, nil)
+	result, err := parseLinesPass(lines, options, fileType, collectTeamVocabulary(lines, fileType, first))
+	result.Detection = detection
+	return result, err
+}
+
+func parseLinesPass(lines []*textLine, options Options, fileType string, vocabulary *teamVocabulary) (Result, error) {
+	p := &lineParser{
+		options:    options,
+		vocabulary: vocabulary,
+		result: Result{
+			Times:         []*SwimmerTime{},
+			RelayTimes:    []*RelayTime{},
+			Events:        []*Event{},
+			ParseErrors:   []*ParseError{},
+			UnparsedLines: []*UnparsedLine{},
+		},
+		fileType: fileType,
+		page:     1,
+		round:    ROUND_TIMED_FINAL,
+	}
+	for _, textLine := range lines {
+		parseErrors := len(p.result.ParseErrors)
+		p.parseLineRecover(textLine)
+		if options.Mode == MODE_STRICT {
+			for _, parseError := range p.result.ParseErrors[parseErrors:] {
+				if parseError.Severity != SEVERITY_INFO {
+					linkRounds(p.result.Times)
+					return p.result, parseError
+				}
 			}
 		}
-		if (processIndividual || processRelay) && (line == " " || line == "" || pageRegex.MatchString(line)) {
-			processIndividual = false
-			processRelay = false
-		}
-		if processIndividual {
-			if line == "Preliminaries" {
-				event.Type = "Preliminaries"
-			} else if strings.HasSuffix(line, "Final") {
-				event.Type = line
-			} else if strings.HasSuffix(line, "Swim-off") {
-				event.Type = "swim-off"
-			} else if strings.HasSuffix(line, "Swim-Off Required") {
-				event.Type = "swim-Off required"
-			} else {
-				if isvalidTime.MatchString(line) {
-					swimmerTime, err := processLine(line, fileType)
-					if err != nil {
-						parseError := ParseError{
-							Type:               "IndividualTime",
-							PartialSwimmerTime: swimmerTime,
-							LineNumber:         i,
-							Line:               line,
-							ErrorMessage:       err.Error(),
-						}
-						result.ParseErrors = append(result.ParseErrors, &parseError)
-					} else {
-						if event == nil || event.Round == "" {
-							parseError := ParseError{
-								Type:         "IndividualTime",
-								LineNumber:   i,
-								Line:         line,
-								ErrorMessage: "event number is empty",
-							}
-							result.ParseErrors = append(result.ParseErrors, &parseError)
+	}
+
+	linkRounds(p.result.Times)
+
+	return p.result, nil
+}
+
+// parseLineRecover parses a line. A panic is recorded as a parse error with the stack,
+// so one malformed line can't stop the parsing of the document.
+func (p *lineParser) parseLineRecover(textLine *textLine) {
+	defer p.recoverLine(textLine)
+	p.parseLine(textLine)
+}
+
+// recoverLine records a panic while parsing the line as a parse error. It has to be deferred.
+func (p *lineParser) recoverLine(textLine *textLine) {
+	if r := recover(); r != nil {
+		parseError := ParseError{
+			Type:         "Panic",
+			Severity:     SEVERITY_ERROR,
+			LineNumber:   textLine.LineNumber,
+			Line:         textLine.Text,
+			ErrorMessage: fmt.Sprintf("panic: %v", r),
+			Stack:        string(debug.Stack()),
+		}
+		p.result.ParseErrors = append(p.result.ParseErrors, &parseError)
+		p.trace(textLine, TraceEvent{Kind: TRACE_REJECT, Message: parseError.ErrorMessage})
+	}
+}
+
+// trace sends an event about the line to the tracer of the options
+func (p *lineParser) trace(textLine *textLine, event TraceEvent) {
+	if p.options.Tracer == nil {
+		return
+	}
+	event.LineNumber = textLine.LineNumber
+	p.options.Tracer.Trace(event)
+}
+
+// traceFields sends the fields of a record parsed from the line to the tracer
+func (p *lineParser) traceFields(textLine *textLine, record interface{}) {
+	if p.options.Tracer == nil {
+		return
+	}
+	for _, event := range traceFields(record) {
+		p.trace(textLine, event)
+	}
+}
+
+// traceClassifiers sends the tests on the line that decide how the line is parsed
+func (p *lineParser) traceClassifiers(textLine *textLine) {
+	if p.options.Tracer == nil {
+		return
+	}
+	line := textLine.Text
+	classifiers := []Classifier{
+		{"isEvent", isEvent(line, p.fileType)},
+		{"isEventContinuation", isEventContinuation(line, p.fileType)},
+		{"isColumnHeader", strings.Contains(line, "Name Age") || isColumnHeader(line)},
+		{"isvalidTime", isvalidTime.MatchString(line)},
+		{"isSplitTimes", splitTimesRegex.MatchString(line)},
+		{"isRoundOrHeat", isRoundOrHeat(line)},
+		{"startsWithPlace", startsWithPlace(line)},
+		{"isRelaySwimmerLine", isRelaySwimmerLine(line)},
+		{"isSectionEnd", isSectionEnd(line)},
+	}
+	for _, classifier := range classifiers {
+		p.trace(textLine, TraceEvent{Kind: TRACE_CLASSIFIER, Rule: classifier.Name, Value: strconv.FormatBool(classifier.Match)})
+	}
+}
+
+// state returns the section the parser is in: individual, relay, suspended individual or relay, or none
+func (p *lineParser) state() string {
+	switch {
+	case p.processIndividual:
+		return SECTION_INDIVIDUAL
+	case p.processRelay:
+		return SECTION_RELAY
+	case p.suspended != "":
+		return "suspended " + p.suspended
+	}
+	return "none"
+}
+
+func (p *lineParser) parseLine(textLine *textLine) {
+	var err error
+	line := textLine.Text
+	if match := pageNumberRegex.FindStringSubmatch(line); match != nil {
+		p.page, _ = strconv.Atoi(match[1])
+	}
+	var source *Source
+	if !p.options.OmitSource {
+		source = lineSource(textLine, p.page)
+	}
+	p.trace(textLine, TraceEvent{Kind: TRACE_LINE, Value: line, Message: p.state()})
+	p.traceClassifiers(textLine)
+	isBreak := line == " " || line == "" || pageNumberRegex.MatchString(line)
+	if (p.processIndividual || p.processRelay) && isBreak {
+		p.suspended = SECTION_INDIVIDUAL
+		if p.processRelay {
+			p.suspended = SECTION_RELAY
+		}
+		p.processIndividual = false
+		p.processRelay = false
+		p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: RULE_BREAK, Message: "suspended " + p.suspended})
+	} else if p.suspended != "" && !isBreak {
+		// the results of the open event continue after the page header, without a repeated table header
+		switch {
+		case isEvent(line, p.fileType) || isSectionEnd(line):
+			p.suspended = ""
+		case p.suspended == SECTION_INDIVIDUAL && (isvalidTime.MatchString(line) || splitTimesRegex.MatchString(line) || isRoundOrHeat(line)):
+			p.processIndividual = true
+			p.suspended = ""
+			p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: RULE_RESUME, Message: SECTION_INDIVIDUAL})
+		case p.suspended == SECTION_RELAY && (startsWithPlace(line) || isRelaySwimmerLine(line) || isRoundOrHeat(line)):
+			p.processRelay = true
+			p.suspended = ""
+			p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: RULE_RESUME, Message: SECTION_RELAY})
+		}
+	}
+	// section of the line, for the coverage
+	section := ""
+	if p.processIndividual {
+		section = SECTION_INDIVIDUAL
+	} else if p.processRelay {
+		section = SECTION_RELAY
+	}
+	parseErrors := len(p.result.ParseErrors)
+	// rule that recognized the line
+	rule := ""
+	if p.processIndividual || p.processRelay {
+		if lineRound, ok := parseRoundMarker(line); ok {
+			p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: RULE_ROUND})
+			p.trace(textLine, TraceEvent{Kind: TRACE_FIELD, Field: "Round", Value: lineRound})
+			p.round = lineRound
+			p.heat = ""
+			if p.event != nil && p.event.Type == "" {
+				p.event.Type = line
+			}
+			p.result.Coverage.add(section, p.event, LINE_RECOGNIZED)
+			return
+		}
+		if lineHeat, ok := parseHeat(line); ok {
+			p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: RULE_HEAT})
+			p.trace(textLine, TraceEvent{Kind: TRACE_FIELD, Field: "Heat", Value: lineHeat})
+			p.heat = lineHeat
+			p.result.Coverage.add(section, p.event, LINE_RECOGNIZED)
+			return
+		}
+	}
+	if p.processIndividual {
+		if strings.HasSuffix(line, "Swim-Off Required") {
+			rule = RULE_SWIM_OFF_REQUIRED
+			p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: rule})
+			if p.event != nil && p.event.Type == "" {
+				p.event.Type = "swim-Off required"
+			}
+		} else {
+			if isvalidTime.MatchString(line) {
+				rule = RULE_INDIVIDUAL_TIME
+				p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: rule})
+				var swimmerTime *SwimmerTime
+				if len(textLine.Words) > 0 && p.wordColumns != nil && p.fileType != FILETYPE_TYPE2 {
+					swimmerTime, err = processLineWords(textLine.Words, p.wordColumns, p.schema)
+				} else {
+					swimmerTime, err = processLine(line, p.fileType, p.schema)
+					if p.vocabulary != nil {
+						settled := false
+						swimmerTime, err, settled = p.vocabulary.settleLine(line, p.fileType, p.schema, swimmerTime, err)
+						if settled {
+							p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: RULE_TEAM_VOCABULARY, Message: "name and team split at " + lineTeam(swimmerTime)})
 						}
-						swimmerTime.Event = event
-						result.Times = append(result.Times, swimmerTime)
 					}
-				} else if splitTimesRegex.MatchString(line) && len(result.Times) > 0 {
-					splitTimes := getSplitTimes(line)
-					result.Times[len(result.Times)-1].SplitTimes = splitTimes
 				}
-			}
-		} else if processRelay {
-			if isRelaySwimmerLine(line) {
-				relaySwimmers, err := processRelaySwimmersLine(line, fileType)
+				p.traceFields(textLine, swimmerTime)
 				if err != nil {
 					parseError := ParseError{
-						Type:         "RelaySwimmer",
-						LineNumber:   i,
-						Line:         line,
-						ErrorMessage: err.Error(),
+						Type:               "IndividualTime",
+						Severity:           SEVERITY_ERROR,
+						PartialSwimmerTime: swimmerTime,
+						LineNumber:         textLine.LineNumber,
+						Line:               line,
+						ErrorMessage:       err.Error(),
 					}
-					result.ParseErrors = append(result.ParseErrors, &parseError)
-				} else {
-					result.RelayTimes[len(result.RelayTimes)-1].Swimmers = append(result.RelayTimes[len(result.RelayTimes)-1].Swimmers, relaySwimmers...)
+					p.result.ParseErrors = append(p.result.ParseErrors, &parseError)
 				}
-			} else {
-				if startsWithNumber(line) {
-					relayTime, err := processRelayLine(line, fileType)
-					if err != nil {
+				if err == nil || p.options.Mode == MODE_LENIENT {
+					swimmerTime.Uncertain = err != nil
+					if p.event == nil || p.event.Round == "" {
 						parseError := ParseError{
-							Type:         "RelayTime",
-							LineNumber:   i,
+							Type:         "IndividualTime",
+							Severity:     SEVERITY_WARNING,
+							LineNumber:   textLine.LineNumber,
 							Line:         line,
-							ErrorMessage: err.Error(),
+							ErrorMessage: "event number is empty",
 						}
-						result.ParseErrors = append(result.ParseErrors, &parseError)
+						p.result.ParseErrors = append(p.result.ParseErrors, &parseError)
+					}
+					swimmerTime.Event = p.event
+					swimmerTime.Source = source
+					swimmerTime.Round = p.round
+					if swimmerTime.Heat == "" {
+						swimmerTime.Heat = p.heat
 					}
-					relayTime.Event = event
-					result.RelayTimes = append(result.RelayTimes, relayTime)
+					p.result.Times = append(p.result.Times, swimmerTime)
 				}
+			} else if splitTimesRegex.MatchString(line) && len(p.result.Times) > 0 {
+				rule = RULE_SPLIT_TIMES
+				p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: rule})
+				splitTimes := getSplitTimes(line)
+				p.trace(textLine, TraceEvent{Kind: TRACE_FIELD, Field: "SplitTimes", Value: strings.Join(splitTimes, " ")})
+				p.result.Times[len(p.result.Times)-1].SplitTimes = splitTimes
 			}
 		}
-
-		if isEvent(line, fileType) {
-			event, err = processEvent(line, fileType)
+	} else if p.processRelay {
+		if isRelaySwimmerLine(line) {
+			rule = RULE_RELAY_SWIMMERS
+			p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: rule})
+			relaySwimmers, err := processRelaySwimmersLine(line, p.fileType)
+			for _, relaySwimmer := range relaySwimmers {
+				p.trace(textLine, TraceEvent{Kind: TRACE_FIELD, Field: "Swimmer " + relaySwimmer.Place, Value: relaySwimmer.Name + " " + relaySwimmer.Age})
+			}
 			if err != nil {
 				parseError := ParseError{
-					Type:         "Event",
-					LineNumber:   i,
+					Type:         "RelaySwimmer",
+					Severity:     SEVERITY_ERROR,
+					LineNumber:   textLine.LineNumber,
 					Line:         line,
 					ErrorMessage: err.Error(),
 				}
-				result.ParseErrors = append(result.ParseErrors, &parseError)
-			} else {
-				result.Events = append(result.Events, event)
+				p.result.ParseErrors = append(p.result.ParseErrors, &parseError)
 			}
-		} else if strings.Contains(line, "Name Age") || strings.Contains(line, "Name Ag  e") || strings.Contains(line, "Name Ag\te") {
-			processIndividual = true
-		} else if strings.Contains(line, "Team  Relay") || (fileType == FILETYPE_TYPE2 && strings.Contains(line, "Pl Team Relay")) {
-			processIndividual = false
-			processRelay = true
-		} else if strings.Contains(line, "Qualifying Times") {
-			if event != nil {
-				err = eventAddQualifyingTimes(result.Events[len(result.Events)-1], line)
+			if p.relay == nil {
+				parseError := ParseError{
+					Type:         "RelaySwimmer",
+					Severity:     SEVERITY_ERROR,
+					LineNumber:   textLine.LineNumber,
+					Line:         line,
+					ErrorMessage: "relay swimmers without a relay",
+				}
+				p.result.ParseErrors = append(p.result.ParseErrors, &parseError)
+			} else if err == nil || p.options.Mode == MODE_LENIENT {
+				for _, relaySwimmer := range relaySwimmers {
+					relaySwimmer.Uncertain = err != nil
+				}
+				p.relay.Swimmers = append(p.relay.Swimmers, relaySwimmers...)
+			}
+		} else {
+			if startsWithPlace(line) {
+				rule = RULE_RELAY_TIME
+				p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: rule})
+				var relayTime *RelayTime
+				if len(textLine.Words) > 0 && p.wordColumns != nil && p.fileType != FILETYPE_TYPE2 {
+					relayTime, err = processRelayLineWords(textLine.Words, p.wordColumns)
+				} else {
+					relayTime, err = processRelayLine(line, p.fileType)
+				}
+				if err != nil && p.vocabulary != nil && p.fileType != FILETYPE_TYPE2 {
+					if settled, settleErr := p.vocabulary.processRelayLine(line); settleErr == nil {
+						relayTime, err = settled, nil
+						p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: RULE_TEAM_VOCABULARY, Message: "team split at " + settled.TeamName})
+					}
+				}
+				p.traceFields(textLine, relayTime)
+				p.relay = nil
 				if err != nil {
 					parseError := ParseError{
-						Type:         "Event",
-						LineNumber:   i,
+						Type:         "RelayTime",
+						Severity:     SEVERITY_ERROR,
+						LineNumber:   textLine.LineNumber,
 						Line:         line,
-						ErrorMessage: "Qualifying Times: " + err.Error(),
+						ErrorMessage: err.Error(),
 					}
-					result.ParseErrors = append(result.ParseErrors, &parseError)
+					p.result.ParseErrors = append(p.result.ParseErrors, &parseError)
+				}
+				if err == nil || p.options.Mode == MODE_LENIENT {
+					relayTime.Uncertain = err != nil
+					relayTime.Event = p.event
+					relayTime.Source = source
+					relayTime.Round = p.round
+					relayTime.Heat = p.heat
+					p.result.RelayTimes = append(p.result.RelayTimes, relayTime)
+					p.relay = relayTime
 				}
 			}
 		}
+	}
 
+	if isEvent(line, p.fileType) {
+		rule = RULE_EVENT
+		p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: rule})
+		p.round = ROUND_TIMED_FINAL
+		p.heat = ""
+		p.relay = nil
+		p.event, err = processEvent(line, p.fileType)
+		p.traceFields(textLine, p.event)
+		if err != nil {
+			parseError := ParseError{
+				Type:         "Event",
+				Severity:     SEVERITY_ERROR,
+				LineNumber:   textLine.LineNumber,
+				Line:         line,
+				ErrorMessage: err.Error(),
+			}
+			p.result.ParseErrors = append(p.result.ParseErrors, &parseError)
+		}
+		if err == nil || p.options.Mode == MODE_LENIENT {
+			p.event.Uncertain = err != nil
+			p.event.Source = source
+			p.result.Events = append(p.result.Events, p.event)
+		} else {
+			p.event = nil // the results can't be added to an event that isn't in the events
+		}
+	} else if isEventContinuation(line, p.fileType) {
+		// line: (Event 12  Girls 10 & Under 50 Yard Freestyle)
+		rule = RULE_EVENT_CONTINUATION
+		p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: rule})
+		continuation, err := processEvent(line, p.fileType)
+		p.traceFields(textLine, continuation)
+		if err != nil {
+			parseError := ParseError{
+				Type:         "Event",
+				Severity:     SEVERITY_ERROR,
+				LineNumber:   textLine.LineNumber,
+				Line:         line,
+				ErrorMessage: err.Error(),
+			}
+			p.result.ParseErrors = append(p.result.ParseErrors, &parseError)
+		}
+		usable := err == nil || p.options.Mode == MODE_LENIENT
+		if usable && (p.event == nil || continuation.Round != p.event.Round || continuation.Relay != p.event.Relay) {
+			parseError := ParseError{
+				Type:         "Event",
+				Severity:     SEVERITY_INFO,
+				LineNumber:   textLine.LineNumber,
+				Line:         line,
+				ErrorMessage: "the start of the event isn't in the results",
+			}
+			p.result.ParseErrors = append(p.result.ParseErrors, &parseError)
+			p.round = ROUND_TIMED_FINAL
+			p.heat = ""
+			p.suspended = ""
+			p.relay = nil
+			p.event = continuation
+			p.event.Uncertain = err != nil
+			p.event.Source = source
+			p.result.Events = append(p.result.Events, p.event)
+		} else if usable && p.suspended != "" {
+			p.processIndividual = p.suspended == SECTION_INDIVIDUAL
+			p.processRelay = p.suspended == SECTION_RELAY
+			p.suspended = ""
+		}
+	} else if strings.Contains(line, "Name Age") || strings.Contains(line, "Name Ag  e") || strings.Contains(line, "Name Ag\te") || isColumnHeader(line) {
+		rule = RULE_INDIVIDUAL_HEADER
+		p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: rule})
+		p.processIndividual = true
+		p.schema = parseColumnSchema(line)
+		if p.schema != nil {
+			p.trace(textLine, TraceEvent{Kind: TRACE_FIELD, Field: "Columns", Value: strings.Join(p.schema.Columns, " ")})
+		}
+		p.wordColumns = nil
+		if len(textLine.Words) > 0 {
+			p.wordColumns = headerWordColumns(textLine.Words)
+		}
+	} else if strings.Contains(line, "Team  Relay") || (p.fileType == FILETYPE_TYPE2 && strings.Contains(line, "Pl Team Relay")) {
+		rule = RULE_RELAY_HEADER
+		p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: rule})
+		p.processIndividual = false
+		p.processRelay = true
+		p.wordColumns = nil
+		if len(textLine.Words) > 0 {
+			p.wordColumns = headerWordColumns(textLine.Words)
+		}
+	} else if strings.Contains(line, "Qualifying Times") {
+		rule = RULE_QUALIFYING_TIMES
+		p.trace(textLine, TraceEvent{Kind: TRACE_RULE, Rule: rule})
+		if p.event != nil {
+			err = eventAddQualifyingTimes(p.event, line)
+			if err != nil {
+				parseError := ParseError{
+					Type:         "Event",
+					Severity:     SEVERITY_WARNING,
+					LineNumber:   textLine.LineNumber,
+					Line:         line,
+					ErrorMessage: "Qualifying Times: " + err.Error(),
+				}
+				p.result.ParseErrors = append(p.result.ParseErrors, &parseError)
+			}
+		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return result, err
+	errored := false
+	for _, parseError := range p.result.ParseErrors[parseErrors:] {
+		if parseError.Severity != SEVERITY_INFO {
+			errored = true
+			p.trace(textLine, TraceEvent{Kind: TRACE_REJECT, Rule: rule, Message: parseError.ErrorMessage})
+		}
 	}
+	if section != "" {
+		status := LINE_RECOGNIZED
+		switch {
+		case errored:
+			status = LINE_ERRORED
+		case rule == "":
+			status = LINE_IGNORED
+			p.trace(textLine, TraceEvent{Kind: TRACE_REJECT, Message: "no rule matched the line in the " + section + " section"})
+			unparsedLine := UnparsedLine{
+				Section:    section,
+				Event:      p.event,
+				LineNumber: textLine.LineNumber,
+				Line:       line,
+			}
+			p.result.UnparsedLines = append(p.result.UnparsedLines, &unparsedLine)
+		}
+		p.result.Coverage.add(section, p.event, status)
+	}
+}
 
-	return result, nil
+// lineSource returns the source of a line. Lines without a page are on the last page number seen in the text.
+func lineSource(textLine *textLine, page int) *Source {
+	source := &Source{
+		Page:       textLine.Page,
+		Column:     textLine.Column,
+		LineNumber: textLine.LineNumber,
+		Line:       textLine.Text,
+	}
+	if source.Page == 0 {
+		source.Page = page
+	}
+	return source
 }
 
 func isRelaySwimmerLine(line string) bool {
 	return strings.HasPrefix(line, "1)") || strings.HasPrefix(line, "2)") || strings.HasPrefix(line, "3)") || strings.HasPrefix(line, "4)")
 }
 
+// isEventContinuation returns true for the header of an event that continues on the next page or column
+// line: (Event 12  Girls 10 & Under 50 Yard Freestyle)
+func isEventContinuation(line string, fileType string) bool {
+	if fileType == FILETYPE_TYPE2 {
+		return false
+	}
+	return strings.HasPrefix(line, "(Event") || strings.HasPrefix(line, "(event")
+}
+
+// isSectionEnd returns true for the tables after the results, which end the open event
+func isSectionEnd(line string) bool {
+	return strings.Contains(line, "Team Scores") || strings.Contains(line, "Team Rankings") || strings.Contains(line, "Scores - ")
+}
+
+func isRoundOrHeat(line string) bool {
+	if _, ok := parseRoundMarker(line); ok {
+		return true
+	}
+	_, ok := parseHeat(line)
+	return ok
+}
+
 func isEvent(line string, fileType string) bool {
 	switch fileType {
 	case FILETYPE_TYPE2:
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestParserSummerSwimTeamResults(t *testing.T) {
@@ -148,7 +150,7 @@ func TestIsEvent(t *testing.T) {
 
 func TestParsePDFTextBadData(t *testing.T) {
 	a := bytes.NewBufferString("faulty\ndata\nEvent 3\nsome data here\n")
-	res, err := parsePDFText(a)
+	res, err := parsePDFText(a, Options{})
 	if err != nil {
 		t.Fatalf("got error: %s", err)
 	}
@@ -175,3 +177,189 @@ func TestEventAddQualifyingTimes(t *testing.T) {
 		t.Fatalf("error: qualifying time 'key' not found. Qualifying times: %+v", event.QualifyingTimes)
 	}
 }
+
+func TestParsePDFTextColumnHeader(t *testing.T) {
+	a := bytes.NewBufferString("Event 1  Girls 100 Yard Freestyle\nName Yr School Seed Time Finals Time Points\n1 Lastname, Firstname SR Lynchburg High School 1:00.00 58.50 20\n2 Lastname, Firstname JR Heritage High School 1:01.00 59.50 17\n")
+	res, err := parsePDFText(a, Options{})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.ParseErrors) > 0 {
+		t.Fatalf("got parse errors: %+v", res.ParseErrors[0])
+	}
+	if len(res.Times) != 2 {
+		t.Fatalf("got %d swimmer times, expected 2", len(res.Times))
+	}
+	if res.Times[1].Grade != "JR" || res.Times[1].TeamName != "Heritage High School" {
+		t.Fatalf("unexpected swimmer time: %+v", res.Times[1])
+	}
+}
+
+func TestParsePDFTextFinalsOnlyTie(t *testing.T) {
+	input := "Event 1  Girls 10 & Under 50 Yard Freestyle\n" +
+		"Name Age Team Finals Time Points\n" +
+		"*1 Lastname, Firstname  10 Lynchburg YMCA 33.10 12.50\n" +
+		"*1 Lastname, Second  9 Heritage Swim 33.10 12.50\n" +
+		"3 Lastname, Third  10 Lynchburg YMCA 34.80 9\n"
+	res, err := parsePDFText(bytes.NewBufferString(input), Options{})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	for _, parseError := range res.ParseErrors {
+		t.Fatalf("parse error: %+v", parseError)
+	}
+	type swim struct {
+		Place         Place
+		SeedTime      string
+		Time          string
+		Points        float64
+		PointsPrinted bool
+	}
+	got := []swim{}
+	for _, swimmerTime := range res.Times {
+		got = append(got, swim{swimmerTime.Place, swimmerTime.SeedTime, swimmerTime.Time, swimmerTime.Points, swimmerTime.PointsPrinted})
+	}
+	expected := []swim{
+		{Place{Value: 1, Tie: true}, "", "33.10", 12.5, true},
+		{Place{Value: 1, Tie: true}, "", "33.10", 12.5, true},
+		{Place{Value: 3}, "", "34.80", 9, true},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParsePDFTextContinuation(t *testing.T) {
+	input := "Event 1  Girls 10 & Under 50 Yard Freestyle\n" +
+		"Name Age Team Seed Time Finals Time Points\n" +
+		"1 Lastname, Firstname  10 Lynchburg YMCA 33.10 32.54 9\n" +
+		"Lynchburg YMCA Summer Invitational - 6/1/2025 Page 1\n" +
+		"Results\n" +
+		"2 Lastname, Second  9 Heritage Swim 34.00 33.80 7\n" +
+		"\n" +
+		"(Event 1  Girls 10 & Under 50 Yard Freestyle)\n" +
+		"3 Lastname, Third  10 Lynchburg YMCA 35.00 34.80 6\n" +
+		"\n" +
+		"Event 2  Girls 10 & Under 200 Yard Freestyle Relay\n" +
+		"Team  Relay Seed Time Finals Time Points\n" +
+		"1 Lynchburg YMCA     A 2:30.00 2:25.10 18\n" +
+		"Lynchburg YMCA Summer Invitational - 6/1/2025 Page 2\n" +
+		"1) Lastname, Firstname 10 2) Lastname, Second 9 3) Lastname, Third 10 4) Lastname, Fourth 9\n" +
+		"2 Heritage Swim     A 2:40.00 2:35.10 14\n" +
+		"\n" +
+		"Combined Team Scores - Girls\n" +
+		"1 Lynchburg YMCA 33\n"
+	res, err := parsePDFText(bytes.NewBufferString(input), Options{})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	for _, parseError := range res.ParseErrors {
+		t.Fatalf("parse error: %+v", parseError)
+	}
+	if len(res.Events) != 2 {
+		t.Fatalf("got %d events, expected 2", len(res.Events))
+	}
+	if len(res.Times) != 3 {
+		t.Fatalf("got %d swimmer times, expected 3", len(res.Times))
+	}
+	for _, swimmerTime := range res.Times {
+		if swimmerTime.Event != res.Events[0] {
+			t.Fatalf("swimmer time of %s is not in the first event", swimmerTime.Name)
+		}
+	}
+	if len(res.RelayTimes) != 2 {
+		t.Fatalf("got %d relay times, expected 2", len(res.RelayTimes))
+	}
+	if len(res.RelayTimes[0].Swimmers) != 4 {
+		t.Fatalf("got %d relay swimmers, expected 4", len(res.RelayTimes[0].Swimmers))
+	}
+}
+
+func TestParsePDFTextSource(t *testing.T) {
+	input := "Lynchburg YMCA Summer Invitational - 6/1/2025 Page 1\n" +
+		"Event 1  Girls 10 & Under 50 Yard Freestyle\n" +
+		"Name Age Team Seed Time Finals Time Points\n" +
+		"1 Lastname, Firstname  10 Lynchburg YMCA 33.10 32.54 9\n" +
+		"\n" +
+		"Lynchburg YMCA Summer Invitational - 6/1/2025 Page 2\n" +
+		"Event 2  Girls 10 & Under 200 Yard Freestyle Relay\n" +
+		"Team  Relay Seed Time Finals Time Points\n" +
+		"1 Lynchburg YMCA     A 2:30.00 2:25.10 18\n"
+	res, err := parsePDFText(bytes.NewBufferString(input), Options{})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.Events) != 2 || len(res.Times) != 1 || len(res.RelayTimes) != 1 {
+		t.Fatalf("got %d events, %d times and %d relay times, expected 2, 1 and 1", len(res.Events), len(res.Times), len(res.RelayTimes))
+	}
+	tests := []struct {
+		got      *Source
+		expected *Source
+	}{
+		{res.Events[0].Source, &Source{Page: 1, LineNumber: 1, Line: "Event 1  Girls 10 & Under 50 Yard Freestyle"}},
+		{res.Times[0].Source, &Source{Page: 1, LineNumber: 3, Line: "1 Lastname, Firstname  10 Lynchburg YMCA 33.10 32.54 9"}},
+		{res.Events[1].Source, &Source{Page: 2, LineNumber: 6, Line: "Event 2  Girls 10 & Under 200 Yard Freestyle Relay"}},
+		{res.RelayTimes[0].Source, &Source{Page: 2, LineNumber: 8, Line: "1 Lynchburg YMCA     A 2:30.00 2:25.10 18"}},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.expected, tt.got); diff != "" {
+			t.Fatalf("mismatch (-want +got):\n%s", diff)
+		}
+	}
+
+	res, err = parsePDFText(bytes.NewBufferString(input), Options{OmitSource: true})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if res.Events[0].Source != nil || res.Times[0].Source != nil || res.RelayTimes[0].Source != nil {
+		t.Fatalf("got a source, expected none")
+	}
+}
+
+func TestParsePDFTextModes(t *testing.T) {
+	input := "Event 1  Girls 10 & Under 50 Yard Freestyle\n" +
+		"Name Age Team Seed Time Finals Time Points\n" +
+		"1 Lastname, Firstname  10 Lynchburg YMCA 33.10 32.54 9\n" +
+		"2 Lastname, Second 34.00 33.80 33.70\n" +
+		"Event 2  Girls 10 & Under 200 Yard Freestyle Relay\n" +
+		"Team  Relay Seed Time Finals Time Points\n" +
+		"1 Forest Swim A 2:30.00 2:25.10 18\n" +
+		"1) Lastname, Firstname 10 2) Lastname, Second 9 3) Lastname, Third 10 4) Lastname, Fourth 9\n" +
+		"2 Heritage Swim     A 2:40.00 2:35.10 14\n"
+	tests := []struct {
+		mode           string
+		expectedTimes  int
+		expectedRelays int
+		expectedErrors int
+		expectedErr    string
+	}{
+		// the relay swimmers of a relay that is left out are not added to the previous relay
+		{MODE_DEFAULT, 1, 1, 3, ""},
+		{MODE_LENIENT, 2, 2, 2, ""},
+		{MODE_STRICT, 1, 0, 1, "line 3 (IndividualTime): error while parsing swimmer name"},
+	}
+	for _, tt := range tests {
+		res, err := parsePDFText(bytes.NewBufferString(input), Options{Mode: tt.mode})
+		if (err == nil && tt.expectedErr != "") || (err != nil && err.Error() != tt.expectedErr) {
+			t.Fatalf("mode '%s': got error %v, expected '%s'", tt.mode, err, tt.expectedErr)
+		}
+		if len(res.Times) != tt.expectedTimes || len(res.RelayTimes) != tt.expectedRelays || len(res.ParseErrors) != tt.expectedErrors {
+			t.Fatalf("mode '%s': got %d times, %d relay times and %d parse errors, expected %d, %d and %d", tt.mode, len(res.Times), len(res.RelayTimes), len(res.ParseErrors), tt.expectedTimes, tt.expectedRelays, tt.expectedErrors)
+		}
+		for _, parseError := range res.ParseErrors {
+			if parseError.Severity != SEVERITY_ERROR {
+				t.Fatalf("mode '%s': got severity '%s', expected error", tt.mode, parseError.Severity)
+			}
+		}
+		for _, swimmerTime := range res.Times {
+			if swimmerTime.Uncertain != (swimmerTime.Place.Value == 2) {
+				t.Fatalf("mode '%s': swimmer time of place %d uncertain: %t", tt.mode, swimmerTime.Place.Value, swimmerTime.Uncertain)
+			}
+		}
+	}
+
+	res, _ := parsePDFText(bytes.NewBufferString(input), Options{Mode: MODE_LENIENT})
+	if !res.RelayTimes[0].Uncertain || len(res.RelayTimes[0].Swimmers) != 4 || res.RelayTimes[1].Uncertain {
+		t.Fatalf("expected the first relay (with the swimmers) uncertain, got %+v", res.RelayTimes)
+	}
+}
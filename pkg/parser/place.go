@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var placeRegex = regexp.MustCompile(`^(?:[*xX]?\d+\*?|-{2,3})\s`)
+var exhibitionTimeRegex = regexp.MustCompile(`(^|\s)[xX]((?:\d{1,2}:)?\d{2}\.\d{2})`)
+
+// parsePlace parses the place column: 1, *3 (tie), x5 (exhibition) or --- (not ranked)
+func parsePlace(s string) (Place, error) {
+	place := Place{}
+	s = strings.TrimSpace(s)
+	if s == "--" || s == "---" || s == "-" {
+		place.Unranked = true
+		return place, nil
+	}
+	if strings.HasPrefix(s, "x") || strings.HasPrefix(s, "X") {
+		place.Exhibition = true
+		s = s[1:]
+	}
+	if strings.HasPrefix(s, "*") || strings.HasSuffix(s, "*") {
+		place.Tie = true
+		s = strings.Trim(s, "*")
+	}
+	value, err := strconv.Atoi(s)
+	if err != nil || value <= 0 {
+		return place, fmt.Errorf("place is not numeric: '%s'", s)
+	}
+	place.Value = value
+	return place, nil
+}
+
+// stripExhibitionMarker removes the x in front of an exhibition time
+// line: Lynchburg YMCA 33.10 x32.54
+func stripExhibitionMarker(line string) (string, bool) {
+	if !exhibitionTimeRegex.MatchString(line) {
+		return line, false
+	}
+	return exhibitionTimeRegex.ReplaceAllString(line, "$1$2"), true
+}
+
+func startsWithPlace(line string) bool {
+	return placeRegex.MatchString(line)
+}
+
+// Scored returns whether the swim can score points
+func (p Place) Scored() bool {
+	return p.Value > 0 && !p.Exhibition && !p.Unranked
+}
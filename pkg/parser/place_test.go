@@ -0,0 +1,56 @@
+package parser
+
+import "testing"
+
+func TestParsePlace(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Place
+		wantErr  bool
+	}{
+		{"1", Place{Value: 1}, false},
+		{"*3", Place{Value: 3, Tie: true}, false},
+		{"3*", Place{Value: 3, Tie: true}, false},
+		{"x5", Place{Value: 5, Exhibition: true}, false},
+		{"---", Place{Unranked: true}, false},
+		{"--", Place{Unranked: true}, false},
+		{"abc", Place{}, true},
+		{"0", Place{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parsePlace(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePlace(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.expected {
+				t.Fatalf("parsePlace(%q) = %+v; want %+v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStripExhibitionMarker(t *testing.T) {
+	line, exhibition := stripExhibitionMarker("Lynchburg YMCA 33.10 x32.54")
+	if !exhibition || line != "Lynchburg YMCA 33.10 32.54" {
+		t.Fatalf("got '%s' (%v)", line, exhibition)
+	}
+	line, exhibition = stripExhibitionMarker("Alex Swim Club 1:02.54")
+	if exhibition || line != "Alex Swim Club 1:02.54" {
+		t.Fatalf("got '%s' (%v)", line, exhibition)
+	}
+}
+
+func TestPlaceString(t *testing.T) {
+	tests := map[string]Place{
+		"1":   {Value: 1},
+		"*3":  {Value: 3, Tie: true},
+		"x5":  {Value: 5, Exhibition: true},
+		"---": {Unranked: true},
+	}
+	for expected, place := range tests {
+		if place.String() != expected {
+			t.Errorf("got %s, want %s", place.String(), expected)
+		}
+	}
+}
@@ -21,11 +21,16 @@ func processRelayLineType2(line string) (*RelayTime, error) {
 	}
 	// line: 1 SwimTeam A SWT 2:05.49 1:26.68
 	// line: 6 SwimTeam A SWT 1:03.12 1:01.48 SWT
+	line, exhibition := stripExhibitionMarker(line)
 	index1 := strings.Index(line, " ")
 	if index1 == -1 {
 		return relayTime, fmt.Errorf("place not found")
 	}
-	relayTime.Place = line[0:index1]
+	relayTime.Place, err = parsePlace(line[0:index1])
+	if err != nil {
+		return relayTime, fmt.Errorf("place not found: %s", err)
+	}
+	relayTime.Place.Exhibition = relayTime.Place.Exhibition || exhibition
 	line = line[index1+1:]
 	// line: SwimTeam A SWT 2:05.49 1:26.68
 	index2 := relayLetterIndex(line)
@@ -63,19 +68,19 @@ func processRelayLineType2(line string) (*RelayTime, error) {
 	// line: 9 INV
 	if line != "" {
 		index7 := strings.Index(line, " ")
-		if index7 == -1 { // line: SWT    or   line: 9
-			if !isNumeric(line) {
+		if index7 == -1 { // line: SWT    or   line: 9    or   line: 17.5
+			if !isDecimal(line) {
 				relayTime.Achievements = line
 			} else {
-				relayTime.Points = line
+				relayTime.setPoints(line)
 			}
 			line = ""
 		} else {
-			if !isNumeric(line[0:index7]) { // line: INV Somethingelse
+			if !isDecimal(line[0:index7]) { // line: INV Somethingelse
 				relayTime.Achievements = line[0:index7]
 				line = line[index7+1:]
 			} else { // line: 9 INV
-				relayTime.Points = line[0:index7]
+				relayTime.setPoints(line[0:index7])
 				line = line[index7+1:]
 			}
 		}
@@ -93,11 +98,16 @@ func processRelayLineType1(line string) (*RelayTime, error) {
 		Swimmers: []*RelaySwimmer{},
 	}
 	// line: 1 Nitro Swimming-ST     A 9:02.07 8:43.46 TAGS 40
+	line, exhibition := stripExhibitionMarker(line)
 	index1 := strings.Index(line, " ")
 	if index1 == -1 {
 		return relayTime, fmt.Errorf("place not found")
 	}
-	relayTime.Place = line[0:index1]
+	relayTime.Place, err = parsePlace(line[0:index1])
+	if err != nil {
+		return relayTime, fmt.Errorf("place not found: %s", err)
+	}
+	relayTime.Place.Exhibition = relayTime.Place.Exhibition || exhibition
 	line = line[index1+1:]
 	// line: Nitro Swimming-ST     A 9:02.07 8:43.46 TAGS 40
 	index2 := strings.Index(line, "     ")
@@ -117,37 +127,54 @@ func processRelayLineType1(line string) (*RelayTime, error) {
 		relayTime.TeamName = line[0:index2]
 	}
 	line = line[index2+index2Offset:]
+	err = processRelayLineType1Entry(relayTime, line)
+	if err != nil {
+		return relayTime, err
+	}
+	return relayTime, nil
+}
 
+// processRelayLineType1Entry parses the relay letter after the team, the times, the points and the qualifying standards
+// line: A 9:02.07 8:43.46 TAGS 40
+func processRelayLineType1Entry(relayTime *RelayTime, line string) error {
+	var err error
 	// line: A 9:02.07 8:43.46 TAGS 40
 	relayLetterIndex := strings.Index(line, " ")
 	if relayLetterIndex == -1 {
-		return relayTime, fmt.Errorf("relay letter index not found")
+		return fmt.Errorf("relay letter index not found")
 	}
 	relayTime.RelayEntry = line[0:relayLetterIndex]
 	line = line[relayLetterIndex+1:]
 
+	// line: 9:02.07 8:43.46 TAGS 40
+	// line: 9:02.07 8:43.46 17.50
+	line, points := splitTrailingPoints(line, 2)
+	if points != "" {
+		relayTime.setPoints(points)
+	}
 	indexRelayLetter := -1
 	indexRelayLetter, relayTime.SeedTime, relayTime.Time, err = processTimes(line)
 	if err != nil {
-		fmt.Printf("Line: %s\n", line)
-		return relayTime, fmt.Errorf("process time error: %s", err)
+		return fmt.Errorf("process time error: %s", err)
 	}
 
 	line = line[indexRelayLetter:]
 
 	// Extract points
 	// line: 9:02.07 8:43.46 TAGS 40
-	if !strings.HasSuffix(line, "  ") {
+	if !strings.HasSuffix(line, "  ") && points == "" {
 		pointsIndex := strings.LastIndex(line, " ")
-		relayTime.Points = line[pointsIndex+1:]
-		line = line[0:pointsIndex]
+		if pointsIndex != -1 && isDecimal(line[pointsIndex+1:]) && !isTime(line[pointsIndex+1:]) {
+			relayTime.setPoints(line[pointsIndex+1:])
+			line = line[0:pointsIndex]
+		}
 	}
 
 	// Extract qualifying standards
 	// line: "9:02.07 8:43.46 TAGS"
 	line = strings.TrimSpace(line) // remove unnecessary spacing
 	qualifyingStandardsIndex := strings.LastIndex(line, " ")
-	if !timesRegex.MatchString(line[qualifyingStandardsIndex+1:]) {
+	if qualifyingStandardsIndex != -1 && !timesRegex.MatchString(line[qualifyingStandardsIndex+1:]) {
 		if line[qualifyingStandardsIndex+1:] != "DQ" && line[qualifyingStandardsIndex+1:] != "NS" && line[qualifyingStandardsIndex+1:] != "DNF" && line[qualifyingStandardsIndex+1:] != "DFS" {
 			relayTime.QualifyingStandards = line[qualifyingStandardsIndex+1:]
 			line = line[0:qualifyingStandardsIndex]
@@ -156,21 +183,19 @@ func processRelayLineType1(line string) (*RelayTime, error) {
 	// line: 10:43.41 Y 9:29.11
 	seedTagIndex := strings.Index(line, " ")
 	if seedTagIndex == -1 {
-		return relayTime, fmt.Errorf("seed tag index not found")
+		return fmt.Errorf("seed tag index not found")
 	}
 	if strings.HasPrefix(line[seedTagIndex+1:], "Y ") || strings.HasPrefix(line[seedTagIndex+1:], "S ") || strings.HasPrefix(line[seedTagIndex+1:], "L ") {
 		relayTime.SeedTimeTag = line[seedTagIndex+1 : seedTagIndex+2]
 		line = line[0:seedTagIndex+1] + line[seedTagIndex+3:]
 	}
 
-	//fmt.Printf("line: %s\n", line)
-
 	err = checkResidual(line)
 	if err != nil {
-		return relayTime, fmt.Errorf("residual information found: '%s'", err)
+		return fmt.Errorf("residual information found: '%s'", err)
 	}
 
-	return relayTime, nil
+	return nil
 }
 
 func processRelaySwimmersLine(line string, fileType string) ([]*RelaySwimmer, error) {
@@ -193,6 +218,9 @@ func processRelaySwimmersLineType2(line string) ([]*RelaySwimmer, error) {
 			return swimmers, fmt.Errorf("couldn't determine place")
 		}
 		relaySwimmer.Place = line[0:index1]
+		if index1+2 > len(line) {
+			return swimmers, fmt.Errorf("couldn't find name")
+		}
 		line = line[index1+2:]
 
 		// line: Lastname, Firstname (6) 2) Raley, Colton (6)
@@ -202,11 +230,11 @@ func processRelaySwimmersLineType2(line string) ([]*RelaySwimmer, error) {
 			break // no swimmer and we're at the end
 		}
 		if (strings.HasPrefix(line, "-") || strings.HasPrefix(line, "—")) && strings.Contains(line, ")") {
-			line = line[2:]
+			line = strings.TrimLeft(strings.TrimLeft(line, "-—"), " ")
 			continue // invalid swimmer, but another one afterwards
 		}
 		index2 := strings.Index(line, "(")
-		if index2 == -1 {
+		if index2 < 1 {
 			return swimmers, fmt.Errorf("couldn't find name")
 		}
 		relaySwimmer.Name = line[0 : index2-1]
@@ -243,6 +271,9 @@ func processRelaySwimmersLineType1(line string) ([]*RelaySwimmer, error) {
 			return swimmers, fmt.Errorf("couldn't determine place")
 		}
 		relaySwimmer.Place = line[0:index1]
+		if index1+2 > len(line) {
+			return swimmers, fmt.Errorf("couldn't find name")
+		}
 		line = line[index1+2:]
 
 		// line: Lastname, Firstname 14 2) Gunn, Pepper 13 3) Peeters, Hanne 14 4) Sitter, Gianna 14
@@ -255,6 +286,9 @@ func processRelaySwimmersLineType1(line string) ([]*RelaySwimmer, error) {
 		} else {
 			offset2 = rightIndex - 2
 		}
+		if offset2 < 0 {
+			return swimmers, fmt.Errorf("expected a name before 'number)'")
+		}
 		// line: Lastname, Firstname 14
 
 		findSpace := strings.LastIndex(line[:offset2], " ")
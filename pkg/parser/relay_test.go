@@ -12,6 +12,9 @@ func TestProcessRelayLine(t *testing.T) {
 		"2 Swimteam2-GU     A 8:46.56 8:45.18 TAGS 34",
 		"3 Swimteam3-NT     A 8:53.15 8:49.90 TAGS 32",
 		"20 Swimteam4-GU     B 9:43.17 9:38.86  ",
+		"*3 Swimteam3-NT     A 8:53.15 8:49.90 TAGS 32",
+		"--- Swimteam4-GU     B 9:43.17 DQ  ",
+		"*4 Swimteam3-NT     A 8:53.15 8:49.90 17.50",
 	}
 	expected := []RelayTime{
 		{
@@ -20,9 +23,10 @@ func TestProcessRelayLine(t *testing.T) {
 			RelayEntry:          "A",
 			Time:                "8:43.46",
 			SeedTime:            "9:02.07",
-			Place:               "1",
+			Place:               Place{Value: 1},
 			QualifyingStandards: "TAGS",
-			Points:              "40",
+			Points:              40,
+			PointsPrinted:       true,
 		},
 		{
 			TeamName:            "Swimteam2",
@@ -30,9 +34,10 @@ func TestProcessRelayLine(t *testing.T) {
 			RelayEntry:          "A",
 			Time:                "8:45.18",
 			SeedTime:            "8:46.56",
-			Place:               "2",
+			Place:               Place{Value: 2},
 			QualifyingStandards: "TAGS",
-			Points:              "34",
+			Points:              34,
+			PointsPrinted:       true,
 		},
 		{ // 3 Swimteam3-NT     A 8:53.15 8:49.90 TAGS 32
 			TeamName:            "Swimteam3",
@@ -40,9 +45,10 @@ func TestProcessRelayLine(t *testing.T) {
 			RelayEntry:          "A",
 			Time:                "8:49.90",
 			SeedTime:            "8:53.15",
-			Place:               "3",
+			Place:               Place{Value: 3},
 			QualifyingStandards: "TAGS",
-			Points:              "32",
+			Points:              32,
+			PointsPrinted:       true,
 		},
 		{ // 20 Swim Streamline at Northampton-GU     B 9:43.17 9:38.86
 			TeamName:            "Swimteam4",
@@ -50,9 +56,38 @@ func TestProcessRelayLine(t *testing.T) {
 			RelayEntry:          "B",
 			Time:                "9:38.86",
 			SeedTime:            "9:43.17",
-			Place:               "20",
+			Place:               Place{Value: 20},
 			QualifyingStandards: "",
-			Points:              "",
+			Points:              0,
+		},
+		{ // *3 Swimteam3-NT     A 8:53.15 8:49.90 TAGS 32
+			TeamName:            "Swimteam3",
+			TeamLSC:             "NT",
+			RelayEntry:          "A",
+			Time:                "8:49.90",
+			SeedTime:            "8:53.15",
+			Place:               Place{Value: 3, Tie: true},
+			QualifyingStandards: "TAGS",
+			Points:              32,
+			PointsPrinted:       true,
+		},
+		{ // --- Swimteam4-GU     B 9:43.17 DQ
+			TeamName:   "Swimteam4",
+			TeamLSC:    "GU",
+			RelayEntry: "B",
+			Time:       "DQ",
+			SeedTime:   "9:43.17",
+			Place:      Place{Unranked: true},
+		},
+		{ // *4 Swimteam3-NT     A 8:53.15 8:49.90 17.50
+			TeamName:      "Swimteam3",
+			TeamLSC:       "NT",
+			RelayEntry:    "A",
+			Time:          "8:49.90",
+			SeedTime:      "8:53.15",
+			Place:         Place{Value: 4, Tie: true},
+			Points:        17.5,
+			PointsPrinted: true,
 		},
 	}
 
@@ -81,7 +116,7 @@ func TestProcessRelayLine(t *testing.T) {
 			t.Fatalf("QualifyingStandards: got: '%s', expected: '%s'", relayTime.QualifyingStandards, expected[k].QualifyingStandards)
 		}
 		if relayTime.Points != expected[k].Points {
-			t.Fatalf("Points: got: '%s', expected: '%s'", relayTime.Points, expected[k].Points)
+			t.Fatalf("Points: got: '%v', expected: '%v'", relayTime.Points, expected[k].Points)
 		}
 	}
 
@@ -92,10 +127,12 @@ func TestProcessRelayLineType2(t *testing.T) {
 		// SWT.txt
 		"1 SwimTeam A SWT 2:05.49 1:26.68",
 		"6 SwimTeam A SWT 1:03.12 1:01.48 SWT",
+		"4 SwimTeam B SWT 2:05.49 x1:26.68",
+		"*2 SwimTeam A SWT 2:05.49 1:26.68 17.5",
 	}
 	expected := []RelayTime{
 		{
-			Place:         "1",
+			Place:         Place{Value: 1},
 			TeamName:      "SwimTeam",
 			TeamNameShort: "SWT",
 			RelayEntry:    "A",
@@ -103,7 +140,7 @@ func TestProcessRelayLineType2(t *testing.T) {
 			Time:          "1:26.68",
 		},
 		{
-			Place:         "6",
+			Place:         Place{Value: 6},
 			TeamName:      "SwimTeam",
 			TeamNameShort: "SWT",
 			RelayEntry:    "A",
@@ -111,6 +148,24 @@ func TestProcessRelayLineType2(t *testing.T) {
 			Time:          "1:01.48",
 			Achievements:  "SWT",
 		},
+		{ // 4 SwimTeam B SWT 2:05.49 x1:26.68
+			Place:         Place{Value: 4, Exhibition: true},
+			TeamName:      "SwimTeam",
+			TeamNameShort: "SWT",
+			RelayEntry:    "B",
+			SeedTime:      "2:05.49",
+			Time:          "1:26.68",
+		},
+		{ // *2 SwimTeam A SWT 2:05.49 1:26.68 17.5
+			Place:         Place{Value: 2, Tie: true},
+			TeamName:      "SwimTeam",
+			TeamNameShort: "SWT",
+			RelayEntry:    "A",
+			SeedTime:      "2:05.49",
+			Time:          "1:26.68",
+			Points:        17.5,
+			PointsPrinted: true,
+		},
 	}
 
 	for k, line := range lines {
@@ -141,7 +196,7 @@ func TestProcessRelayLineType2(t *testing.T) {
 			t.Fatalf("QualifyingStandards: got: '%s', expected: '%s'", relayTime.QualifyingStandards, expected[k].QualifyingStandards)
 		}
 		if relayTime.Points != expected[k].Points {
-			t.Fatalf("Points: got: '%s', expected: '%s'", relayTime.Points, expected[k].Points)
+			t.Fatalf("Points: got: '%v', expected: '%v'", relayTime.Points, expected[k].Points)
 		}
 	}
 
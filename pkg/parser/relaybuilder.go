@@ -0,0 +1,263 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// CourseFactors converts times between courses: a time in a course is the short course yards time times its factor
+var CourseFactors = map[string]float64{
+	COURSE_SCY: 1.0,
+	COURSE_SCM: 1.11,
+	COURSE_LCM: 1.14,
+}
+
+var medleyOrder = []string{"Backstroke", "Breaststroke", "Butterfly", "Freestyle"}
+
+type RelayBuilderOptions struct {
+	// Freestyle or Medley
+	Stroke      string
+	LegDistance int
+	// course of the relay. Times swum in other courses are converted
+	Course string
+	// girls, boys or mixed (two girls and two boys)
+	Gender string
+	// the age of every swimmer has to be in the age group (empty = open)
+	AgeGroup string
+	// only the swimmers of the team. When empty, the relays of every team are built
+	Team string
+	// swimmer names. When Available is empty, every swimmer is available
+	Available   []string
+	Unavailable []string
+	// number of relays to build (A, B, C, ...). Defaults to 3
+	Relays int
+}
+
+type RelayLineup struct {
+	Team       string      `json:"team"`
+	RelayEntry string      `json:"relay"`
+	Time       string      `json:"time"`
+	Legs       []*RelayLeg `json:"legs"`
+}
+
+type RelayLeg struct {
+	Stroke string `json:"stroke"`
+	Name   string `json:"name"`
+	Age    string `json:"age"`
+	Gender string `json:"gender"`
+	// the converted time used to build the relay
+	Time        string       `json:"time"`
+	SwimmerTime *SwimmerTime `json:"swimmerTime"`
+}
+
+type relayCandidate struct {
+	team   string
+	name   string
+	age    string
+	gender string
+	// best converted time per stroke, in hundredths
+	times map[string]int
+	swims map[string]*SwimmerTime
+}
+
+// BuildRelays returns the fastest relays (A, B, C, ...) of every team that can be built with the individual times
+// of the swimmers. Medley legs are assigned optimally; every next relay is built with the swimmers that are left.
+func BuildRelays(times []*SwimmerTime, options RelayBuilderOptions) ([]*RelayLineup, error) {
+	legStrokes := []string{"Freestyle", "Freestyle", "Freestyle", "Freestyle"}
+	switch normalizeStroke(options.Stroke) {
+	case "Freestyle":
+	case "IM":
+		legStrokes = medleyOrder
+	default:
+		return nil, fmt.Errorf("relay stroke not supported: %s", options.Stroke)
+	}
+	if _, ok := CourseFactors[options.Course]; !ok {
+		return nil, fmt.Errorf("unknown course: %s", options.Course)
+	}
+	gender := normalizeGender(options.Gender)
+	if gender != "girls" && gender != "boys" && gender != "mixed" {
+		return nil, fmt.Errorf("unknown relay gender: %s", options.Gender)
+	}
+	relays := options.Relays
+	if relays == 0 {
+		relays = 3
+	}
+
+	lineups := []*RelayLineup{}
+	candidates := relayCandidates(times, options)
+	teams := []string{}
+	for _, candidate := range candidates {
+		if !slices.Contains(teams, candidate.team) {
+			teams = append(teams, candidate.team)
+		}
+	}
+	for _, team := range teams {
+		teamCandidates := slices.DeleteFunc(slices.Clone(candidates), func(c *relayCandidate) bool { return c.team != team })
+		lineups = append(lineups, buildTeamRelays(team, teamCandidates, legStrokes, gender, relays)...)
+	}
+	return lineups, nil
+}
+
+// buildTeamRelays builds the relays of one team with the candidates of the team
+func buildTeamRelays(team string, candidates []*relayCandidate, legStrokes []string, gender string, relays int) []*RelayLineup {
+	lineups := []*RelayLineup{}
+	for i := 0; i < relays; i++ {
+		legs := fastestRelay(candidates, legStrokes, gender)
+		if legs == nil {
+			break
+		}
+		lineup := &RelayLineup{
+			Team:       team,
+			RelayEntry: string(rune('A' + i)),
+			Legs:       []*RelayLeg{},
+		}
+		total := 0
+		for k, candidate := range legs {
+			total += candidate.times[legStrokes[k]]
+			lineup.Legs = append(lineup.Legs, &RelayLeg{
+				Stroke:      legStrokes[k],
+				Name:        candidate.name,
+				Age:         candidate.age,
+				Gender:      candidate.gender,
+				Time:        formatHundredths(candidate.times[legStrokes[k]]),
+				SwimmerTime: candidate.swims[legStrokes[k]],
+			})
+			candidates = slices.DeleteFunc(candidates, func(c *relayCandidate) bool { return c == candidate })
+		}
+		lineup.Time = formatHundredths(total)
+		lineups = append(lineups, lineup)
+	}
+	return lineups
+}
+
+// relayCandidates returns the best times of the swimmers. Swimmers with the same name in different teams are
+// different candidates.
+func relayCandidates(times []*SwimmerTime, options RelayBuilderOptions) []*relayCandidate {
+	type swimmerKey struct{ team, name string }
+	candidatesByName := map[swimmerKey]*relayCandidate{}
+	candidates := []*relayCandidate{}
+	for _, swimmerTime := range times {
+		event := swimmerTime.Event
+		if event == nil || event.Relay {
+			continue
+		}
+		if options.Team != "" && swimmerTime.TeamName != options.Team {
+			continue
+		}
+		if len(options.Available) > 0 && !slices.Contains(options.Available, swimmerTime.Name) {
+			continue
+		}
+		if slices.Contains(options.Unavailable, swimmerTime.Name) {
+			continue
+		}
+		distance, course := parseCourse(event.Distance)
+		if distance != options.LegDistance {
+			continue
+		}
+		factor, ok := CourseFactors[course]
+		if !ok {
+			continue
+		}
+		time, err := timeToHundredths(swimmerTime.Time)
+		if err != nil {
+			continue
+		}
+		if options.AgeGroup != "" {
+			age, err := strconv.Atoi(swimmerTime.Age)
+			if err != nil {
+				continue
+			}
+			if contains, err := ageGroupContains(options.AgeGroup, age); err != nil || !contains {
+				continue
+			}
+		}
+		converted := int(math.Round(float64(time) / factor * CourseFactors[options.Course]))
+		stroke := normalizeStroke(event.Stroke)
+
+		key := swimmerKey{swimmerTime.TeamName, swimmerTime.Name}
+		candidate, ok := candidatesByName[key]
+		if !ok {
+			candidate = &relayCandidate{
+				team:  swimmerTime.TeamName,
+				name:  swimmerTime.Name,
+				times: map[string]int{},
+				swims: map[string]*SwimmerTime{},
+			}
+			candidatesByName[key] = candidate
+			candidates = append(candidates, candidate)
+		}
+		candidate.age = swimmerTime.Age
+		if g := normalizeGender(event.Gender); g == "girls" || g == "boys" {
+			candidate.gender = g
+		}
+		if current, ok := candidate.times[stroke]; !ok || converted < current {
+			candidate.times[stroke] = converted
+			candidate.swims[stroke] = swimmerTime
+		}
+	}
+	return candidates
+}
+
+// fastestRelay picks one swimmer per leg. An optimal relay only uses swimmers that are in the top 4 of their gender
+// for the stroke of their leg, so only those are tried.
+func fastestRelay(candidates []*relayCandidate, legStrokes []string, gender string) []*relayCandidate {
+	legCandidates := make([][]*relayCandidate, len(legStrokes))
+	for k, stroke := range legStrokes {
+		perGender := map[string][]*relayCandidate{}
+		for _, candidate := range candidates {
+			if _, ok := candidate.times[stroke]; !ok {
+				continue
+			}
+			if gender == "mixed" && candidate.gender == "" {
+				continue
+			}
+			if gender != "mixed" && candidate.gender != gender {
+				continue
+			}
+			perGender[candidate.gender] = append(perGender[candidate.gender], candidate)
+		}
+		for _, g := range []string{"girls", "boys"} {
+			list := perGender[g]
+			sort.SliceStable(list, func(i, j int) bool { return list[i].times[stroke] < list[j].times[stroke] })
+			legCandidates[k] = append(legCandidates[k], list[:min(len(list), len(legStrokes))]...)
+		}
+	}
+
+	var best []*relayCandidate
+	bestTime := -1
+	current := make([]*relayCandidate, len(legStrokes))
+	var search func(leg int, total int)
+	search = func(leg int, total int) {
+		if bestTime != -1 && total >= bestTime {
+			return
+		}
+		if leg == len(legStrokes) {
+			if gender == "mixed" {
+				girls := 0
+				for _, candidate := range current {
+					if candidate.gender == "girls" {
+						girls++
+					}
+				}
+				if girls != len(legStrokes)/2 {
+					return
+				}
+			}
+			best = slices.Clone(current)
+			bestTime = total
+			return
+		}
+		for _, candidate := range legCandidates[leg] {
+			if slices.Contains(current[:leg], candidate) {
+				continue
+			}
+			current[leg] = candidate
+			search(leg+1, total+candidate.times[legStrokes[leg]])
+		}
+	}
+	search(0, 0)
+	return best
+}
@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBuildRelaysMedley(t *testing.T) {
+	event := func(stroke string, distance string) *Event {
+		return &Event{Gender: "girls", AgeGroup: "11-12", Distance: distance, Stroke: stroke}
+	}
+	times := []*SwimmerTime{
+		// A is the fastest in back and fly, but the relay is faster with A on fly
+		{Event: event("Backstroke", "50 Yard"), Name: "A", Age: "12", Time: "30.00"},
+		{Event: event("Fly", "50 Yard"), Name: "A", Age: "12", Time: "28.00"},
+		{Event: event("Backstroke", "50 Yard"), Name: "B", Age: "12", Time: "30.50"},
+		{Event: event("Fly", "50 Yard"), Name: "B", Age: "12", Time: "32.00"},
+		{Event: event("Breaststroke", "50 Yard"), Name: "C", Age: "11", Time: "35.00"},
+		{Event: event("Freestyle", "50 Yard"), Name: "D", Age: "12", Time: "27.00"},
+		{Event: event("Freestyle", "50 LC Meter"), Name: "E", Age: "12", Time: "30.78"},
+		{Event: event("Breaststroke", "50 Yard"), Name: "E", Age: "12", Time: "36.00"},
+		{Event: event("Freestyle", "50 Yard"), Name: "F", Age: "13", Time: "25.00"},
+	}
+	lineups, err := BuildRelays(times, RelayBuilderOptions{
+		Stroke:      "Medley",
+		LegDistance: 50,
+		Course:      COURSE_SCY,
+		Gender:      "girls",
+		AgeGroup:    "11-12",
+		Relays:      2,
+	})
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	if len(lineups) != 1 {
+		t.Fatalf("expected 1 relay, got %d", len(lineups))
+	}
+	names := []string{}
+	for _, leg := range lineups[0].Legs {
+		names = append(names, leg.Stroke+" "+leg.Name+" "+leg.Time)
+	}
+	expected := []string{"Backstroke B 30.50", "Breaststroke C 35.00", "Butterfly A 28.00", "Freestyle D 27.00"}
+	if diff := cmp.Diff(expected, names); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+	if lineups[0].Time != "2:00.50" {
+		t.Fatalf("expected 2:00.50, got %s", lineups[0].Time)
+	}
+}
+
+func TestBuildRelaysMixedFreestyle(t *testing.T) {
+	girls := &Event{Gender: "girls", Distance: "50 Yard", Stroke: "Freestyle"}
+	boys := &Event{Gender: "boys", Distance: "50 Yard", Stroke: "Freestyle"}
+	times := []*SwimmerTime{
+		{Event: boys, Name: "B1", Time: "24.00"},
+		{Event: boys, Name: "B2", Time: "24.50"},
+		{Event: boys, Name: "B3", Time: "25.00"},
+		{Event: girls, Name: "G1", Time: "26.00"},
+		{Event: girls, Name: "G2", Time: "27.00"},
+		{Event: girls, Name: "G3", Time: "28.00"},
+	}
+	lineups, err := BuildRelays(times, RelayBuilderOptions{
+		Stroke:      "Freestyle",
+		LegDistance: 50,
+		Course:      COURSE_SCY,
+		Gender:      "mixed",
+		Unavailable: []string{"G2"},
+	})
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	if len(lineups) != 1 {
+		t.Fatalf("expected 1 relay, got %d", len(lineups))
+	}
+	if lineups[0].Time != "1:42.50" {
+		t.Fatalf("expected 1:42.50, got %s", lineups[0].Time)
+	}
+}
+
+func TestBuildRelaysTeams(t *testing.T) {
+	free := &Event{Gender: "boys", Distance: "50 Yard", Stroke: "Freestyle"}
+	back := &Event{Gender: "boys", Distance: "50 Yard", Stroke: "Backstroke"}
+	times := []*SwimmerTime{
+		// two swimmers named Smith, John: their times aren't mixed and they don't swim in one relay
+		{Event: free, Name: "Smith, John", TeamName: "T1", Time: "25.00"},
+		{Event: back, Name: "Smith, John", TeamName: "T2", Time: "28.00"},
+		{Event: free, Name: "Smith, John", TeamName: "T2", Time: "29.00"},
+		{Event: free, Name: "A", TeamName: "T1", Time: "26.00"},
+		{Event: free, Name: "B", TeamName: "T1", Time: "27.00"},
+		{Event: free, Name: "C", TeamName: "T1", Time: "28.00"},
+		{Event: free, Name: "D", TeamName: "T2", Time: "26.50"},
+		{Event: free, Name: "E", TeamName: "T2", Time: "27.50"},
+		{Event: free, Name: "F", TeamName: "T2", Time: "28.50"},
+	}
+	lineups, err := BuildRelays(times, RelayBuilderOptions{
+		Stroke:      "Freestyle",
+		LegDistance: 50,
+		Course:      COURSE_SCY,
+		Gender:      "boys",
+	})
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	got := []string{}
+	for _, lineup := range lineups {
+		got = append(got, lineup.Team+" "+lineup.RelayEntry+" "+lineup.Time)
+	}
+	expected := []string{"T1 A 1:46.00", "T2 A 1:51.50"}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}
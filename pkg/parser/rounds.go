@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	ROUND_TIMED_FINAL = ""
+	ROUND_PRELIM      = "prelim"
+	ROUND_FINAL       = "final"
+	ROUND_FINAL_A     = "a-final"
+	ROUND_FINAL_B     = "b-final"
+	ROUND_FINAL_C     = "c-final"
+	ROUND_SWIM_OFF    = "swim-off"
+)
+
+var heatRegex = regexp.MustCompile(`^Heat\s+(\d+)\b`)
+
+// parseRoundMarker recognizes the lines that start a round within an event.
+// The second return value is false when the line isn't a round marker.
+// line: Preliminaries
+// line: A - Final
+// line: Swim-off
+func parseRoundMarker(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	lower := strings.ToLower(line)
+	switch {
+	case lower == "preliminaries" || lower == "prelims":
+		return ROUND_PRELIM, true
+	case lower == "finals" || lower == "final":
+		return ROUND_FINAL, true
+	case strings.HasSuffix(lower, "swim-off required"):
+		return "", false // annotation on the prelims, not a round
+	case strings.HasSuffix(lower, "swim-off"):
+		return ROUND_SWIM_OFF, true
+	case strings.HasSuffix(line, "Final"):
+		switch {
+		case strings.HasPrefix(lower, "a ") || strings.HasPrefix(lower, "a-") || strings.HasPrefix(lower, "championship"):
+			return ROUND_FINAL_A, true
+		case strings.HasPrefix(lower, "b ") || strings.HasPrefix(lower, "b-") || strings.HasPrefix(lower, "consolation"):
+			return ROUND_FINAL_B, true
+		case strings.HasPrefix(lower, "c ") || strings.HasPrefix(lower, "c-") || strings.HasPrefix(lower, "bonus"):
+			return ROUND_FINAL_C, true
+		}
+		return ROUND_FINAL, true
+	}
+	return "", false
+}
+
+// parseHeat returns the heat number of a heat line
+// line: Heat 2 of 5 Finals
+func parseHeat(line string) (string, bool) {
+	match := heatRegex.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+func isFinalRound(round string) bool {
+	return round == ROUND_FINAL || round == ROUND_FINAL_A || round == ROUND_FINAL_B || round == ROUND_FINAL_C
+}
+
+// linkRounds links the prelim swim of a swimmer to the final of the same event:
+// the prelim swim gets the finals time, the final gets the prelim time
+func linkRounds(times []*SwimmerTime) {
+	prelims := map[string]*SwimmerTime{}
+	key := func(swimmerTime *SwimmerTime) string {
+		eventNumber := ""
+		if swimmerTime.Event != nil {
+			eventNumber = swimmerTime.Event.Round
+		}
+		return eventNumber + "|" + swimmerTime.Name + "|" + swimmerTime.TeamName
+	}
+	for _, swimmerTime := range times {
+		if swimmerTime.Round == ROUND_PRELIM {
+			prelims[key(swimmerTime)] = swimmerTime
+		}
+	}
+	for _, swimmerTime := range times {
+		if !isFinalRound(swimmerTime.Round) {
+			continue
+		}
+		prelim, ok := prelims[key(swimmerTime)]
+		if !ok {
+			continue
+		}
+		prelim.Finals = swimmerTime.Time
+		prelim.FinalsRound = swimmerTime.Round
+		if swimmerTime.PrelimTime == "" {
+			swimmerTime.PrelimTime = prelim.Time
+		}
+	}
+}
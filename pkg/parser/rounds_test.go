@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseRoundMarker(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected string
+		ok       bool
+	}{
+		{"Preliminaries", ROUND_PRELIM, true},
+		{"A - Final", ROUND_FINAL_A, true},
+		{"B - Final", ROUND_FINAL_B, true},
+		{"C - Final", ROUND_FINAL_C, true},
+		{"Championship Final", ROUND_FINAL_A, true},
+		{"Consolation Final", ROUND_FINAL_B, true},
+		{"Finals", ROUND_FINAL, true},
+		{"Swim-off", ROUND_SWIM_OFF, true},
+		{"Swim-Off Required", "", false},
+		{"1 Lastname, Firstname  14 Lynchburg YMCA 2:14.96 2:16.72", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := parseRoundMarker(tt.line)
+			if got != tt.expected || ok != tt.ok {
+				t.Fatalf("parseRoundMarker(%q) = (%q, %v); want (%q, %v)", tt.line, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParsePDFTextRounds(t *testing.T) {
+	text := `Event 1  Girls 13-14 100 Yard Freestyle
+Name Age Team Seed Time Finals Time Points
+A - Final
+1 Lastname, Alice  14 Lynchburg YMCA 59.00 58.10 9
+2 Lastname, Beth  13 Lynchburg YMCA 59.50 59.20 7
+B - Final
+9 Lastname, Cara  14 Lynchburg YMCA 1:02.00 1:01.10 
+Preliminaries
+Heat 1 of 2
+1 Lastname, Alice  14 Lynchburg YMCA 1:00.00 59.00 q
+2 Lastname, Beth  13 Lynchburg YMCA 1:00.50 59.50 q
+Heat 2 of 2
+9 Lastname, Cara  14 Lynchburg YMCA 1:03.00 1:02.00 q
+12 Lastname, Dana  14 Lynchburg YMCA 1:04.00 1:03.00
+`
+	result, err := parsePDFText(bytes.NewBufferString(text), Options{})
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	if len(result.ParseErrors) > 0 {
+		t.Fatalf("parse error: %+v", result.ParseErrors[0])
+	}
+	if len(result.Times) != 7 {
+		t.Fatalf("expected 7 times, got %d", len(result.Times))
+	}
+	expected := []struct {
+		round      string
+		heat       string
+		finals     string
+		prelimTime string
+	}{
+		{ROUND_FINAL_A, "", "", "59.00"},
+		{ROUND_FINAL_A, "", "", "59.50"},
+		{ROUND_FINAL_B, "", "", "1:02.00"},
+		{ROUND_PRELIM, "1", "58.10", ""},
+		{ROUND_PRELIM, "1", "59.20", ""},
+		{ROUND_PRELIM, "2", "1:01.10", ""},
+		{ROUND_PRELIM, "2", "", ""},
+	}
+	for k, swimmerTime := range result.Times {
+		if swimmerTime.Round != expected[k].round || swimmerTime.Heat != expected[k].heat || swimmerTime.Finals != expected[k].finals || swimmerTime.PrelimTime != expected[k].prelimTime {
+			t.Fatalf("time %d: got round '%s', heat '%s', finals '%s', prelim time '%s', expected %+v", k, swimmerTime.Round, swimmerTime.Heat, swimmerTime.Finals, swimmerTime.PrelimTime, expected[k])
+		}
+	}
+	if result.Events[0].Type != "A - Final" {
+		t.Fatalf("expected event type 'A - Final', got '%s'", result.Events[0].Type)
+	}
+}
@@ -0,0 +1,277 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+type ScoringTable struct {
+	Name string `json:"name"`
+	// points per place, starting with first place
+	Individual []float64 `json:"individual"`
+	// points per place for relays. When empty, Individual * RelayMultiplier is used
+	Relay           []float64 `json:"relay,omitempty"`
+	RelayMultiplier float64   `json:"relayMultiplier,omitempty"`
+	// maximum number of swims per team that score in one event (0 = unlimited)
+	MaxScorersPerTeam int  `json:"maxScorersPerTeam,omitempty"`
+	ScoreExhibition   bool `json:"scoreExhibition,omitempty"`
+}
+
+var ScoringDualMeet = &ScoringTable{
+	Name:       "dual",
+	Individual: []float64{6, 4, 3, 2, 1},
+	Relay:      []float64{8, 4, 2},
+}
+var ScoringChampionship6 = &ScoringTable{
+	Name:            "championship-6",
+	Individual:      []float64{7, 5, 4, 3, 2, 1},
+	RelayMultiplier: 2,
+}
+var ScoringChampionship8 = &ScoringTable{
+	Name:            "championship-8",
+	Individual:      []float64{9, 7, 6, 5, 4, 3, 2, 1},
+	RelayMultiplier: 2,
+}
+var ScoringChampionship10 = &ScoringTable{
+	Name:            "championship-10",
+	Individual:      []float64{11, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+	RelayMultiplier: 2,
+}
+var ScoringChampionship16 = &ScoringTable{
+	Name:            "championship-16",
+	Individual:      []float64{20, 17, 16, 15, 14, 13, 12, 11, 9, 7, 6, 5, 4, 3, 2, 1},
+	RelayMultiplier: 2,
+}
+var ScoringChampionship20 = &ScoringTable{
+	Name:            "championship-20",
+	Individual:      []float64{25, 22, 21, 20, 19, 18, 17, 16, 14, 12, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+	RelayMultiplier: 2,
+}
+var ScoringChampionship24 = &ScoringTable{
+	Name:            "championship-24",
+	Individual:      []float64{32, 28, 27, 26, 25, 24, 23, 22, 20, 17, 16, 15, 14, 13, 12, 11, 9, 7, 6, 5, 4, 3, 2, 1},
+	RelayMultiplier: 2,
+}
+
+var ScoringTables = map[string]*ScoringTable{
+	ScoringDualMeet.Name:       ScoringDualMeet,
+	ScoringChampionship6.Name:  ScoringChampionship6,
+	ScoringChampionship8.Name:  ScoringChampionship8,
+	ScoringChampionship10.Name: ScoringChampionship10,
+	ScoringChampionship16.Name: ScoringChampionship16,
+	ScoringChampionship20.Name: ScoringChampionship20,
+	ScoringChampionship24.Name: ScoringChampionship24,
+}
+
+type ScoredSwim struct {
+	Event       *Event       `json:"event"`
+	SwimmerTime *SwimmerTime `json:"swimmerTime,omitempty"`
+	RelayTime   *RelayTime   `json:"relayTime,omitempty"`
+	Team        string       `json:"team"`
+	Place       int          `json:"place"`
+	Points      float64      `json:"points"`
+}
+
+type PointsMismatch struct {
+	Event          *Event  `json:"event"`
+	Place          Place   `json:"place"`
+	Name           string  `json:"name"`
+	Team           string  `json:"team"`
+	PrintedPoints  float64 `json:"printedPoints"`
+	ExpectedPoints float64 `json:"expectedPoints"`
+	Source         *Source `json:"source,omitempty"`
+}
+
+// LoadScoringTable returns one of the built-in tables by name, or loads a table from a json file
+func LoadScoringTable(nameOrPath string) (*ScoringTable, error) {
+	if table, ok := ScoringTables[nameOrPath]; ok {
+		return table, nil
+	}
+	data, err := os.ReadFile(nameOrPath)
+	if err != nil {
+		return nil, fmt.Errorf("scoring table '%s' not found: %s", nameOrPath, err)
+	}
+	table := &ScoringTable{}
+	if err := json.Unmarshal(data, table); err != nil {
+		return nil, fmt.Errorf("couldn't parse scoring table: %s", err)
+	}
+	if len(table.Individual) == 0 {
+		return nil, fmt.Errorf("scoring table has no individual points")
+	}
+	return table, nil
+}
+
+func (t *ScoringTable) pointsForPlace(place int, relay bool) float64 {
+	if relay {
+		if len(t.Relay) > 0 {
+			if place < len(t.Relay) {
+				return t.Relay[place]
+			}
+			return 0
+		}
+		multiplier := t.RelayMultiplier
+		if multiplier == 0 {
+			multiplier = 1
+		}
+		if place < len(t.Individual) {
+			return t.Individual[place] * multiplier
+		}
+		return 0
+	}
+	if place < len(t.Individual) {
+		return t.Individual[place]
+	}
+	return 0
+}
+
+// Score computes the points every swim in the result should get with this table
+func (t *ScoringTable) Score(result Result) []*ScoredSwim {
+	scored := []*ScoredSwim{}
+	for _, event := range result.Events {
+		swims := []*ScoredSwim{}
+		for _, swimmerTime := range result.Times {
+			if swimmerTime.Event == event && scoredRound(swimmerTime.Round) {
+				swims = append(swims, &ScoredSwim{Event: event, SwimmerTime: swimmerTime, Team: swimmerTime.TeamName})
+			}
+		}
+		for _, relayTime := range result.RelayTimes {
+			if relayTime.Event == event && scoredRound(relayTime.Round) {
+				swims = append(swims, &ScoredSwim{Event: event, RelayTime: relayTime, Team: relayTeam(relayTime)})
+			}
+		}
+		scored = append(scored, t.scoreEvent(swims, event.Relay)...)
+	}
+	return scored
+}
+
+// scoreEvent ranks the swims of one event by place. Swims that can't score (no place, exhibition,
+// over the team limit) don't take a scoring place. Tied swims split the points of the places they cover.
+func (t *ScoringTable) scoreEvent(swims []*ScoredSwim, relay bool) []*ScoredSwim {
+	eligible := []*ScoredSwim{}
+	for _, swim := range swims {
+		place := swim.place()
+		if place.Value <= 0 || place.Unranked || (place.Exhibition && !t.ScoreExhibition) {
+			continue
+		}
+		swim.Place = place.Value
+		eligible = append(eligible, swim)
+	}
+	sort.SliceStable(eligible, func(i, j int) bool {
+		return eligible[i].Place < eligible[j].Place
+	})
+
+	teamScorers := map[string]int{}
+	scoringPlace := 0
+	for i := 0; i < len(eligible); {
+		tied := []*ScoredSwim{}
+		j := i
+		for ; j < len(eligible) && eligible[j].Place == eligible[i].Place; j++ {
+			swim := eligible[j]
+			if t.MaxScorersPerTeam > 0 && teamScorers[swim.Team] >= t.MaxScorersPerTeam {
+				continue
+			}
+			// counted right away, so teammates tied at the place can't score over the limit
+			teamScorers[swim.Team]++
+			tied = append(tied, swim)
+		}
+		total := 0.0
+		for k := range tied {
+			total += t.pointsForPlace(scoringPlace+k, relay)
+		}
+		for _, swim := range tied {
+			swim.Points = total / float64(len(tied))
+		}
+		scoringPlace += len(tied)
+		i = j
+	}
+	return swims
+}
+
+func (s *ScoredSwim) place() Place {
+	if s.SwimmerTime != nil {
+		return s.SwimmerTime.Place
+	}
+	if s.RelayTime != nil {
+		return s.RelayTime.Place
+	}
+	return Place{Unranked: true}
+}
+
+func (s *ScoredSwim) pointsPrinted() bool {
+	if s.SwimmerTime != nil {
+		return s.SwimmerTime.PointsPrinted
+	}
+	return s.RelayTime.PointsPrinted
+}
+
+func (s *ScoredSwim) printedPoints() float64 {
+	if s.SwimmerTime != nil {
+		return s.SwimmerTime.Points
+	}
+	return s.RelayTime.Points
+}
+
+// TeamScores adds up the points per team
+func TeamScores(scored []*ScoredSwim) map[string]float64 {
+	scores := map[string]float64{}
+	for _, swim := range scored {
+		if swim.Points > 0 {
+			scores[swim.Team] += swim.Points
+		}
+	}
+	return scores
+}
+
+// VerifyPoints compares the points printed in the results with the points expected by the scoring table.
+// Events without any printed points are skipped.
+func (t *ScoringTable) VerifyPoints(result Result) []*PointsMismatch {
+	mismatches := []*PointsMismatch{}
+	scored := t.Score(result)
+	eventHasPoints := map[*Event]bool{}
+	for _, swim := range scored {
+		if swim.pointsPrinted() {
+			eventHasPoints[swim.Event] = true
+		}
+	}
+	for _, swim := range scored {
+		if !eventHasPoints[swim.Event] {
+			continue
+		}
+		if math.Abs(swim.printedPoints()-swim.Points) < 0.001 {
+			continue
+		}
+		mismatch := &PointsMismatch{
+			Event:          swim.Event,
+			Team:           swim.Team,
+			PrintedPoints:  swim.printedPoints(),
+			ExpectedPoints: swim.Points,
+		}
+		if swim.SwimmerTime != nil {
+			mismatch.Place = swim.SwimmerTime.Place
+			mismatch.Name = swim.SwimmerTime.Name
+			mismatch.Source = swim.SwimmerTime.Source
+		} else {
+			mismatch.Place = swim.RelayTime.Place
+			mismatch.Name = strings.TrimSpace(swim.RelayTime.TeamName + " " + swim.RelayTime.RelayEntry)
+			mismatch.Source = swim.RelayTime.Source
+		}
+		mismatches = append(mismatches, mismatch)
+	}
+	return mismatches
+}
+
+// only timed finals and finals score, prelims and swim-offs don't
+func scoredRound(round string) bool {
+	return round == ROUND_TIMED_FINAL || isFinalRound(round)
+}
+
+func relayTeam(relayTime *RelayTime) string {
+	if relayTime.TeamNameShort != "" {
+		return relayTime.TeamNameShort
+	}
+	return relayTime.TeamName
+}
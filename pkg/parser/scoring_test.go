@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScoringTableScore(t *testing.T) {
+	individual := &Event{Round: "1"}
+	relay := &Event{Round: "2", Relay: true}
+	result := Result{
+		Events: []*Event{individual, relay},
+		Times: []*SwimmerTime{
+			{Event: individual, Place: Place{Value: 1}, Name: "A", TeamName: "T1", Time: "30.00", Points: 9, PointsPrinted: true},
+			{Event: individual, Place: Place{Value: 2, Tie: true}, Name: "B", TeamName: "T2", Time: "31.00", Points: 6.5, PointsPrinted: true},
+			{Event: individual, Place: Place{Value: 2, Tie: true}, Name: "C", TeamName: "T1", Time: "31.00", Points: 6.5, PointsPrinted: true},
+			{Event: individual, Place: Place{Value: 4, Exhibition: true}, Name: "D", TeamName: "T2", Time: "32.00"},
+			{Event: individual, Place: Place{Value: 4}, Name: "E", TeamName: "T3", Time: "33.00", Points: 5, PointsPrinted: true},
+			{Event: individual, Place: Place{Unranked: true}, Name: "F", TeamName: "T3", Time: "DQ"},
+		},
+		RelayTimes: []*RelayTime{
+			{Event: relay, Place: Place{Value: 1}, TeamName: "T1", RelayEntry: "A", Time: "2:00.00", Points: 18, PointsPrinted: true},
+			{Event: relay, Place: Place{Value: 2}, TeamName: "T2", RelayEntry: "A", Time: "2:01.00", Points: 12, PointsPrinted: true},
+		},
+	}
+	scored := ScoringChampionship8.Score(result)
+	expected := map[string]float64{"A": 9, "B": 6.5, "C": 6.5, "D": 0, "E": 5, "F": 0, "T1 A": 18, "T2 A": 14}
+	for _, swim := range scored {
+		name := ""
+		if swim.SwimmerTime != nil {
+			name = swim.SwimmerTime.Name
+		} else {
+			name = swim.RelayTime.TeamName + " " + swim.RelayTime.RelayEntry
+		}
+		if swim.Points != expected[name] {
+			t.Fatalf("%s: got %v points, expected %v", name, swim.Points, expected[name])
+		}
+	}
+
+	mismatches := ScoringChampionship8.VerifyPoints(result)
+	if len(mismatches) != 1 {
+		t.Fatalf("expected 1 mismatch, got %d", len(mismatches))
+	}
+	if mismatches[0].Name != "T2 A" || mismatches[0].PrintedPoints != 12 || mismatches[0].ExpectedPoints != 14 {
+		t.Fatalf("unexpected mismatch: %+v", mismatches[0])
+	}
+
+	teamScores := TeamScores(scored)
+	if diff := cmp.Diff(map[string]float64{"T1": 33.5, "T2": 20.5, "T3": 5}, teamScores); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestScoringTableVerifyPointsPrintedZero(t *testing.T) {
+	event := &Event{Round: "1"}
+	result := Result{
+		Events: []*Event{event},
+		Times: []*SwimmerTime{
+			{Event: event, Place: Place{Value: 1}, Name: "A", TeamName: "T1", Time: "30.00", Points: 0, PointsPrinted: true},
+			{Event: event, Place: Place{Value: 2}, Name: "B", TeamName: "T2", Time: "31.00", Points: 0, PointsPrinted: true},
+		},
+	}
+	// 0 points printed are checked, no points printed aren't
+	if mismatches := ScoringChampionship8.VerifyPoints(result); len(mismatches) != 2 {
+		t.Fatalf("expected 2 mismatches, got %d", len(mismatches))
+	}
+	for _, swimmerTime := range result.Times {
+		swimmerTime.PointsPrinted = false
+	}
+	if mismatches := ScoringChampionship8.VerifyPoints(result); len(mismatches) != 0 {
+		t.Fatalf("expected no mismatches, got %d", len(mismatches))
+	}
+}
+
+func TestScoringTableMaxScorersPerTeam(t *testing.T) {
+	event := &Event{Round: "1"}
+	result := Result{
+		Events: []*Event{event},
+		Times: []*SwimmerTime{
+			{Event: event, Place: Place{Value: 1}, Name: "A", TeamName: "T1"},
+			{Event: event, Place: Place{Value: 2}, Name: "B", TeamName: "T1"},
+			{Event: event, Place: Place{Value: 3}, Name: "C", TeamName: "T2"},
+		},
+	}
+	table := &ScoringTable{Individual: []float64{5, 3, 1}, MaxScorersPerTeam: 1}
+	scored := table.Score(result)
+	points := []float64{}
+	for _, swim := range scored {
+		points = append(points, swim.Points)
+	}
+	if diff := cmp.Diff([]float64{5, 0, 3}, points); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+
+	// teammates tied at a place: only the first one scores
+	result.Times = []*SwimmerTime{
+		{Event: event, Place: Place{Value: 1, Tie: true}, Name: "A", TeamName: "T1"},
+		{Event: event, Place: Place{Value: 1, Tie: true}, Name: "B", TeamName: "T1"},
+		{Event: event, Place: Place{Value: 3}, Name: "C", TeamName: "T2"},
+	}
+	points = []float64{}
+	for _, swim := range table.Score(result) {
+		points = append(points, swim.Points)
+	}
+	if diff := cmp.Diff([]float64{5, 0, 3}, points); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}
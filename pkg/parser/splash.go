@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"fmt"
+	"sync"
+)
+
+// the results of Splash Meet Manager, used by European and international meets
+// line: Event 5  Men, 100m Freestyle
+// line: 1. SMITH John 2001 GBR 48.12 912
+// line: 50m: 23.10  100m: 48.12 (25.02)
+// line: 1. Team Sweden SWE 3:35.10 912
+// line: LARSSON Anna 2001  SJOSTROM Sarah 1993  HANSSON Louise 1996  COLEMAN Michelle 1993
+
+const (
+	// SURNAME Firstname
+	splashName = `\p{Lu}[\p{Lu}'\-]*(?:\s\p{Lu}[\p{Lu}'\-]*)*\s\p{Lu}\p{Ll}[\p{L}'\-]*(?:[\s\-]\p{Lu}\p{Ll}[\p{L}'\-]*)*`
+	// a time, or the status of a swim without a time
+	splashTime = `(?:\d{1,2}:)?\d{1,2}\.\d{2}|DSQ|DNS|DNF|WDR`
+	// qualification for the next round (Q), reserve (R), the World Aquatics points and the records
+	splashResult = `(?:\s+(?P<qualifyingStandards>Q|q|R))?(?:\s+(?P<worldAquaticsPoints>\d+))?(?:\s+(?P<achievements>(?:WR|ER|CR|OR|NR|WJ|EJ)(?:\s+(?:WR|ER|CR|OR|NR|WJ|EJ))*))?\s*$`
+)
+
+var splashDefinition = &FormatDefinition{
+	Name:       FILETYPE_SPLASH,
+	SectionEnd: []string{`^\s*(?:Team Scores|Medal Table|Medal Standings)`},
+	Event: &LineRule{
+		Pattern: `^\s*Event\s+(?P<number>\d+)\s+(?P<gender>Women|Men|Mixed|Girls|Boys)(?:\s+(?P<ageGroup>[^,]+?))?,\s*(?:(?P<legs>\d)\s*x\s*)?(?P<distance>\d+\s?m)\s+(?P<stroke>Freestyle|Backstroke|Breaststroke|Butterfly|Individual Medley|Medley)(?:\s+(?P<type>.+?))?\s*$`,
+		Process: map[string][]string{
+			"gender":   {"lower"},
+			"ageGroup": {"ageGroup"},
+			"stroke":   {"replace:Individual Medley:IM"},
+		},
+	},
+	Lines: []*LineRule{
+		{
+			Name:    "splash individual time",
+			Record:  RECORD_INDIVIDUAL,
+			Pattern: `^\s*(?:(?P<place>\d+)\.?\s+)?(?P<name>` + splashName + `)\s+(?P<yearOfBirth>\d{4}|\d{2})\s+(?P<team>.+?)(?:\s+Heat\s+(?P<heat>\d+)\s+Lane\s+(?P<lane>\d+))?\s+(?P<time>` + splashTime + `)` + splashResult,
+			Process: map[string][]string{"name": {"surnameFirst"}},
+		},
+		{
+			Name:    "splash relay time",
+			Record:  RECORD_RELAY,
+			Pattern: `^\s*(?:(?P<place>\d+)\.?\s+)?(?P<team>.+?)(?:\s+(?P<relay>[A-H]))?(?:\s+Heat\s+(?P<heat>\d+)\s+Lane\s+\d+)?\s+(?P<time>` + splashTime + `)` + splashResult,
+		},
+		{
+			Name:    "splash relay swimmers",
+			Record:  RECORD_RELAY_SWIMMERS,
+			Pattern: `(?:(?P<leg>[1-4])\.\s+)?(?P<name>` + splashName + `)\s+(?P<yearOfBirth>\d{4}|\d{2})\b`,
+			Process: map[string][]string{"name": {"surnameFirst"}},
+		},
+		{
+			// the lap times in parentheses aren't split times
+			Name:    "splash split times",
+			Record:  RECORD_SPLITS,
+			Pattern: `^\s*(?P<splits>\d+m:.*)$`,
+			Process: map[string][]string{"splits": {"dropParentheses"}},
+		},
+		{
+			Name:    "splash heat",
+			Record:  RECORD_HEAT,
+			Pattern: `^\s*Heat\s+(?P<heat>\d+)(?:\s+of\s+\d+)?\s*$`,
+		},
+		{
+			Name:    "splash round",
+			Record:  RECORD_ROUND,
+			Pattern: `^\s*(?P<round>Final|Finals|A-Final|B-Final|Semifinals?|Semi-Finals?|Heats|Preliminaries|Swim-off)\s*$`,
+			Process: map[string][]string{"round": {"replace:Heats:Preliminaries"}},
+		},
+		{
+			// the column header, the points legend and the page footer
+			Name:    "splash header",
+			Record:  RECORD_IGNORE,
+			Pattern: `^\s*(?:(?:Rank|Rk\.?|Place)\s+Name\b|Points: |Splash Meet Manager)`,
+		},
+	},
+}
+
+// splashFormat compiles the format definition the first time the format is used
+var splashFormat = sync.OnceValues(func() (*Format, error) {
+	format, err := CompileFormat(splashDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("format %s: %w", splashDefinition.Name, err)
+	}
+	return format, nil
+})
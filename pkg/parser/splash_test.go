@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePDFTextSplash(t *testing.T) {
+	input := "European Junior Championships                 Splash Meet Manager, 11.80123\n" +
+		"Event 5  Men, 100m Freestyle\n" +
+		"Final\n" +
+		"Rank  Name  YoB  Nation  Time  Points\n" +
+		"1.  MÜLLER Max  2006  GER  48.95  Q  845\n" +
+		"50m:  23.50   100m:  48.95 (25.45)\n" +
+		"2.  VAN DER BERG Jan-Willem  2007  NED - Netherlands  Heat 2 Lane 4  49.10  838  EJ\n" +
+		"DOE John  2006  GBR  DSQ\n" +
+		"Points: World Aquatics 2024\n" +
+		"Event 6  Women 14-15, 4 x 100m Medley\n" +
+		"1.  Team Sweden  A  4:05.32  790\n" +
+		"LARSSON Anna 2008  SJÖSTRÖM Sara 2009  HANSSON Louise 2008  COLEMAN Michelle 2009\n" +
+		"2.  Great Britain  4:07.10\n" +
+		"Team Scores\n"
+	result, err := parsePDFText(bytes.NewBufferString(input), Options{OmitSource: true})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if result.Detection.Winner.Format != FILETYPE_SPLASH {
+		t.Fatalf("got format %s, expected %s", result.Detection, FILETYPE_SPLASH)
+	}
+	for _, parseError := range result.ParseErrors {
+		t.Fatalf("parse error: %+v", parseError)
+	}
+	if len(result.UnparsedLines) != 0 {
+		t.Fatalf("got unparsed lines: %+v", result.UnparsedLines[0])
+	}
+
+	expectedEvents := []*Event{
+		{Round: "5", Gender: "men", Distance: "100m", Stroke: "Freestyle", QualifyingTimes: map[string]string{}},
+		{Round: "6", Gender: "women", AgeGroup: "14-15", Distance: "400m", Stroke: "Medley", Relay: true, QualifyingTimes: map[string]string{}},
+	}
+	if diff := cmp.Diff(expectedEvents, result.Events); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+
+	type swim struct {
+		Name, YearOfBirth, TeamName, Round, Heat, Lane, Time string
+		Place                                                Place
+		WorldAquaticsPoints                                  int
+		QualifyingStandards, Achievements                    string
+		SplitTimes                                           []string
+	}
+	got := []swim{}
+	for _, swimmerTime := range result.Times {
+		got = append(got, swim{swimmerTime.Name, swimmerTime.YearOfBirth, swimmerTime.TeamName, swimmerTime.Round, swimmerTime.Heat, swimmerTime.Lane,
+			swimmerTime.Time, swimmerTime.Place, swimmerTime.WorldAquaticsPoints, swimmerTime.QualifyingStandards, swimmerTime.Achievements, swimmerTime.SplitTimes})
+	}
+	expected := []swim{
+		{"Müller, Max", "2006", "GER", ROUND_FINAL, "", "", "48.95", Place{Value: 1}, 845, "Q", "", []string{"23.50", "48.95"}},
+		{"Van Der Berg, Jan-Willem", "2007", "NED - Netherlands", ROUND_FINAL, "2", "4", "49.10", Place{Value: 2}, 838, "", "EJ", nil},
+		{"Doe, John", "2006", "GBR", ROUND_FINAL, "", "", "DSQ", Place{Unranked: true}, 0, "", "", nil},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(result.RelayTimes) != 2 {
+		t.Fatalf("got %d relay times, expected 2", len(result.RelayTimes))
+	}
+	relayTime := result.RelayTimes[0]
+	if relayTime.TeamName != "Team Sweden" || relayTime.RelayEntry != "A" || relayTime.Time != "4:05.32" || relayTime.WorldAquaticsPoints != 790 || relayTime.PointsPrinted {
+		t.Fatalf("unexpected relay time: %+v", relayTime)
+	}
+	swimmers := []RelaySwimmer{}
+	for _, relaySwimmer := range relayTime.Swimmers {
+		swimmers = append(swimmers, *relaySwimmer)
+	}
+	expectedSwimmers := []RelaySwimmer{
+		{Name: "Larsson, Anna", YearOfBirth: "2008"},
+		{Name: "Sjöström, Sara", YearOfBirth: "2009"},
+		{Name: "Hansson, Louise", YearOfBirth: "2008"},
+		{Name: "Coleman, Michelle", YearOfBirth: "2009"},
+	}
+	if diff := cmp.Diff(expectedSwimmers, swimmers); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+	if result.RelayTimes[1].TeamName != "Great Britain" || result.RelayTimes[1].Place.Value != 2 || len(result.RelayTimes[1].Swimmers) != 0 {
+		t.Fatalf("unexpected relay time: %+v", result.RelayTimes[1])
+	}
+}
+
+func TestSplashFormat(t *testing.T) {
+	if _, err := splashFormat(); err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+}
@@ -0,0 +1,410 @@
+package parser
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type Standards struct {
+	Standards   []*TimeStandard `json:"standards"`
+	ParseErrors []*ParseError   `json:"parseErrors"`
+}
+
+type TimeStandard struct {
+	Name     string `json:"name"`
+	Gender   string `json:"gender"`
+	AgeGroup string `json:"ageGroup"`
+	Distance string `json:"distance"`
+	Stroke   string `json:"stroke"`
+	Course   string `json:"course"`
+	Time     string `json:"time"`
+}
+
+type standardsGroup struct {
+	Gender   string
+	AgeGroup string
+}
+
+var standardsGenderRegex = regexp.MustCompile(`(?i)\b(girls|boys|women|men|female|male)\b`)
+var standardsAgeRegex = regexp.MustCompile(`(?i)\b(?:\d{1,2}\s*&\s*(?:under|over|o)\b|\d{1,2}\s*-\s*\d{1,2}\b|open\b)`)
+var standardNameRegex = regexp.MustCompile(`^[A-Z][A-Z0-9+\-]{0,9}$`)
+var standardNameWithEventRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9+\-]{0,11}$`)
+var standardsEventColumnRegex = regexp.MustCompile(`(?i)\bevents?\b`)
+
+// the usual standard names: a header of only these names is a header, other headers without an event
+// column (USA SWIMMING) have to be followed by a row with a time for every name
+var knownStandardNames = map[string]bool{"B": true, "BB": true, "A": true, "AA": true, "AAA": true, "AAAA": true, "Q": true, "JO": true, "FUT": true, "SECT": true}
+
+var standardsCourseRegex = regexp.MustCompile(`(?i)^(?:short course yards|short course meters|long course meters|scy|scm|lcm)$`)
+
+func ParseStandardsText(filePath string) (Standards, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return Standards{}, err
+	}
+	defer file.Close()
+	return parseStandardsText(file)
+}
+
+// parseStandardsText reads a time standards table. Supported layouts:
+//
+//	Girls 10 & Under
+//	B BB A AA AAA AAAA
+//	50 Yard Freestyle 36.19 33.39 30.59 29.19 27.79 26.39
+//
+// and the mirrored layout with the event in the middle:
+//
+//	10 & Under Girls 10 & Under Boys
+//	B BB A AA Event AA A BB B
+//	36.19 33.39 30.59 29.19 50 FR SCY 28.79 30.19 32.99 35.79
+func parseStandardsText(reader io.Reader) (Standards, error) {
+	standards := Standards{
+		Standards:   []*TimeStandard{},
+		ParseErrors: []*ParseError{},
+	}
+	scanner := bufio.NewScanner(reader)
+	groups := []standardsGroup{}
+	leftNames := []string{}
+	rightNames := []string{}
+	// names of a header without an event column that isn't confirmed by the next row yet
+	var pendingNames []string
+	course := ""
+
+	for i := 0; scanner.Scan(); i++ {
+		line := strings.TrimSpace(strings.ReplaceAll(scanner.Text(), "\t", " "))
+		if line == "" {
+			continue
+		}
+		if standardsCourseRegex.MatchString(line) {
+			course = parseStandardsCourse(line)
+			continue
+		}
+		if lineGroups := parseStandardsGroups(line); len(lineGroups) > 0 {
+			groups = lineGroups
+			continue
+		}
+		if left, right, ok := parseStandardsHeader(line); ok {
+			if left == nil && !allKnownStandardNames(right) {
+				pendingNames = right
+				continue
+			}
+			leftNames, rightNames = left, right
+			pendingNames = nil
+			continue
+		}
+		if !timesRegex.MatchString(line) {
+			continue
+		}
+		if pendingNames != nil {
+			if countTimes(line) == len(pendingNames) {
+				leftNames, rightNames = nil, pendingNames
+			}
+			pendingNames = nil
+		}
+		rowStandards, err := processStandardsLine(line, groups, leftNames, rightNames, course)
+		if err != nil {
+			standards.ParseErrors = append(standards.ParseErrors, &ParseError{
+				Type:         "TimeStandard",
+				Severity:     SEVERITY_ERROR,
+				LineNumber:   i,
+				Line:         line,
+				ErrorMessage: err.Error(),
+			})
+			continue
+		}
+		standards.Standards = append(standards.Standards, rowStandards...)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return standards, err
+	}
+	return standards, nil
+}
+
+// parseStandardsGroups finds the gender/age group titles of a table
+// line: 10 & Under Girls 10 & Under Boys
+func parseStandardsGroups(line string) []standardsGroup {
+	if timesRegex.MatchString(line) {
+		return nil
+	}
+	genders := standardsGenderRegex.FindAllStringIndex(line, -1)
+	ages := standardsAgeRegex.FindAllStringIndex(line, -1)
+	if len(genders) == 0 || len(ages) == 0 {
+		return nil
+	}
+	groups := []standardsGroup{}
+	for k, gender := range genders {
+		age := ages[0]
+		if len(ages) == len(genders) {
+			age = ages[k]
+		}
+		ageGroup := strings.ToLower(line[age[0]:age[1]])
+		groups = append(groups, standardsGroup{
+			Gender:   normalizeGender(line[gender[0]:gender[1]]),
+			AgeGroup: normalizeAge(ageGroup),
+		})
+	}
+	return groups
+}
+
+// parseStandardsHeader returns the standard names left and right of the event column
+// line: B BB A AA AAA AAAA
+// line: B BB A Event A BB B
+func parseStandardsHeader(line string) ([]string, []string, bool) {
+	left := []string{}
+	right := []string{}
+	eventSeen := false
+	nameRegex := standardNameRegex
+	if standardsEventColumnRegex.MatchString(line) {
+		nameRegex = standardNameWithEventRegex // the event column marks this line as a header, allow names like Sectionals
+	}
+	for _, field := range strings.Fields(line) {
+		if strings.EqualFold(field, "event") || strings.EqualFold(field, "events") {
+			if eventSeen {
+				return nil, nil, false
+			}
+			eventSeen = true
+			continue
+		}
+		if !nameRegex.MatchString(field) {
+			return nil, nil, false
+		}
+		if eventSeen {
+			right = append(right, field)
+		} else {
+			left = append(left, field)
+		}
+	}
+	if len(left)+len(right) == 0 {
+		return nil, nil, false
+	}
+	if !eventSeen {
+		return nil, left, true // event column comes first
+	}
+	return left, right, true
+}
+
+func allKnownStandardNames(names []string) bool {
+	for _, name := range names {
+		if !knownStandardNames[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// countTimes returns the number of words of the line that are a time
+func countTimes(line string) int {
+	count := 0
+	for _, field := range strings.Fields(line) {
+		if isTime(field) {
+			count++
+		}
+	}
+	return count
+}
+
+func processStandardsLine(line string, groups []standardsGroup, leftNames []string, rightNames []string, course string) ([]*TimeStandard, error) {
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("no gender / age group found before times")
+	}
+	if len(leftNames)+len(rightNames) == 0 {
+		return nil, fmt.Errorf("no standard names found before times")
+	}
+	// line: 36.19 33.39 30.59 29.19 50 FR SCY 28.79 30.19 32.99 35.79
+	fields := strings.Fields(line)
+	leftTimes := []string{}
+	rightTimes := []string{}
+	eventFields := []string{}
+	for _, field := range fields {
+		if isTime(field) {
+			if len(eventFields) == 0 {
+				leftTimes = append(leftTimes, field)
+			} else {
+				rightTimes = append(rightTimes, field)
+			}
+			continue
+		}
+		if len(rightTimes) > 0 {
+			return nil, fmt.Errorf("unexpected value after times: '%s'", field)
+		}
+		eventFields = append(eventFields, field)
+	}
+	if len(eventFields) == 0 {
+		return nil, fmt.Errorf("event not found")
+	}
+	distance, stroke, eventCourse, err := parseStandardsEvent(strings.Join(eventFields, " "))
+	if err != nil {
+		return nil, err
+	}
+	if eventCourse != "" {
+		course = eventCourse
+	}
+
+	out := []*TimeStandard{}
+	// times are aligned to the event column: missing times are on the outside of the table
+	add := func(group standardsGroup, names []string, times []string, alignRight bool) error {
+		if len(times) > len(names) {
+			return fmt.Errorf("found %d times, but only %d standard names", len(times), len(names))
+		}
+		offset := 0
+		if alignRight {
+			offset = len(names) - len(times)
+		}
+		for k, time := range times {
+			out = append(out, &TimeStandard{
+				Name:     names[k+offset],
+				Gender:   group.Gender,
+				AgeGroup: group.AgeGroup,
+				Distance: distance,
+				Stroke:   stroke,
+				Course:   course,
+				Time:     time,
+			})
+		}
+		return nil
+	}
+
+	if len(leftTimes) > 0 {
+		if len(leftNames) == 0 {
+			return nil, fmt.Errorf("times found before the event, but no standard names")
+		}
+		if err := add(groups[0], leftNames, leftTimes, true); err != nil {
+			return nil, err
+		}
+	}
+	if len(rightTimes) > 0 {
+		if len(rightNames) == 0 {
+			return nil, fmt.Errorf("times found after the event, but no standard names")
+		}
+		group := groups[0]
+		if len(leftNames) > 0 {
+			group = groups[len(groups)-1] // mirrored table: the right side is the last group
+		}
+		if err := add(group, rightNames, rightTimes, false); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// parseStandardsEvent parses the event column of a standards table
+// line: 50 FR SCY
+// line: 100 Yard Freestyle
+// line: 200 LC Meter IM
+func parseStandardsEvent(line string) (string, string, string, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || !isNumeric(fields[0]) {
+		return "", "", "", fmt.Errorf("can't extract distance from: %s", line)
+	}
+	distance := fields[0]
+	course := ""
+	unit := []string{}
+	stroke := ""
+	for _, field := range fields[1:] {
+		switch strings.ToUpper(field) {
+		case "SCY", "SCM", "LCM":
+			course = strings.ToUpper(field)
+			continue
+		case "Y", "YD", "YDS", "YARD", "YARDS", "LC", "SC", "M", "METER", "METERS":
+			unit = append(unit, field)
+			continue
+		}
+		if stroke != "" {
+			stroke += " "
+		}
+		stroke += field
+	}
+	if stroke == "" {
+		return "", "", "", fmt.Errorf("stroke not found: %s", line)
+	}
+	if course == "" && len(unit) > 0 {
+		_, course = parseCourse(distance + " " + strings.Join(unit, " "))
+		if strings.HasPrefix(strings.ToUpper(unit[0]), "Y") {
+			course = COURSE_SCY
+		}
+	}
+	parsedStroke, _, err := parseStroke(strokeAbbreviation(stroke))
+	if err != nil {
+		return "", "", "", err
+	}
+	return distance, normalizeStroke(parsedStroke), course, nil
+}
+
+func strokeAbbreviation(stroke string) string {
+	switch strings.ToUpper(stroke) {
+	case "FR", "FREE":
+		return "Freestyle"
+	case "BK":
+		return "Backstroke"
+	case "BR", "BREAST":
+		return "Breaststroke"
+	case "FL":
+		return "Fly"
+	}
+	return stroke
+}
+
+func parseStandardsCourse(line string) string {
+	switch strings.ToLower(line) {
+	case "short course yards", "scy":
+		return COURSE_SCY
+	case "short course meters", "scm":
+		return COURSE_SCM
+	}
+	return COURSE_LCM
+}
+
+func LoadStandards(filePath string) (Standards, error) {
+	standards := Standards{}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return standards, err
+	}
+	err = json.Unmarshal(data, &standards)
+	return standards, err
+}
+
+// Achieved returns the names of the standards the swim is as fast as or faster than
+func (s Standards) Achieved(swimmerTime *SwimmerTime) []string {
+	achieved := []string{}
+	if swimmerTime == nil || swimmerTime.Event == nil {
+		return achieved
+	}
+	swimTime, err := timeToHundredths(swimmerTime.Time)
+	if err != nil {
+		return achieved
+	}
+	distance, course := parseCourse(swimmerTime.Event.Distance)
+	age, ageErr := strconv.Atoi(swimmerTime.Age)
+	for _, standard := range s.Standards {
+		if standard.Distance != strconv.Itoa(distance) || standard.Course != course {
+			continue
+		}
+		if standard.Stroke != normalizeStroke(swimmerTime.Event.Stroke) || standard.Gender != normalizeGender(swimmerTime.Event.Gender) {
+			continue
+		}
+		if standard.AgeGroup != swimmerTime.Event.AgeGroup {
+			if ageErr != nil {
+				continue
+			}
+			if contains, err := ageGroupContains(standard.AgeGroup, age); err != nil || !contains {
+				continue
+			}
+		}
+		standardTime, err := timeToHundredths(standard.Time)
+		if err != nil {
+			continue
+		}
+		if swimTime <= standardTime {
+			achieved = append(achieved, standard.Name)
+		}
+	}
+	return achieved
+}
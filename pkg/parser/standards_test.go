@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseStandardsText(t *testing.T) {
+	text := `2024-2028 Motivational Standards
+10 & Under Girls 10 & Under Boys
+B BB A AA Event AA A BB B
+36.19 33.39 30.59 29.19 50 FR SCY 28.79 30.19 32.99 35.79
+1:19.69 1:13.29 100 BK SCY 1:12.09 1:18.49
+Long Course Meters
+Girls 11-12
+B BB A
+50 Freestyle 35.69 32.89 30.29
+USA SWIMMING
+100 Freestyle 1:18.49 1:12.29 1:06.09
+`
+	standards, err := parseStandardsText(bytes.NewBufferString(text))
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	if len(standards.ParseErrors) > 0 {
+		t.Fatalf("parse errors: %+v", standards.ParseErrors[0])
+	}
+	expected := []*TimeStandard{
+		{Name: "B", Gender: "girls", AgeGroup: "10 & under", Distance: "50", Stroke: "Freestyle", Course: "SCY", Time: "36.19"},
+		{Name: "BB", Gender: "girls", AgeGroup: "10 & under", Distance: "50", Stroke: "Freestyle", Course: "SCY", Time: "33.39"},
+		{Name: "A", Gender: "girls", AgeGroup: "10 & under", Distance: "50", Stroke: "Freestyle", Course: "SCY", Time: "30.59"},
+		{Name: "AA", Gender: "girls", AgeGroup: "10 & under", Distance: "50", Stroke: "Freestyle", Course: "SCY", Time: "29.19"},
+		{Name: "AA", Gender: "boys", AgeGroup: "10 & under", Distance: "50", Stroke: "Freestyle", Course: "SCY", Time: "28.79"},
+		{Name: "A", Gender: "boys", AgeGroup: "10 & under", Distance: "50", Stroke: "Freestyle", Course: "SCY", Time: "30.19"},
+		{Name: "BB", Gender: "boys", AgeGroup: "10 & under", Distance: "50", Stroke: "Freestyle", Course: "SCY", Time: "32.99"},
+		{Name: "B", Gender: "boys", AgeGroup: "10 & under", Distance: "50", Stroke: "Freestyle", Course: "SCY", Time: "35.79"},
+		{Name: "A", Gender: "girls", AgeGroup: "10 & under", Distance: "100", Stroke: "Backstroke", Course: "SCY", Time: "1:19.69"},
+		{Name: "AA", Gender: "girls", AgeGroup: "10 & under", Distance: "100", Stroke: "Backstroke", Course: "SCY", Time: "1:13.29"},
+		{Name: "AA", Gender: "boys", AgeGroup: "10 & under", Distance: "100", Stroke: "Backstroke", Course: "SCY", Time: "1:12.09"},
+		{Name: "A", Gender: "boys", AgeGroup: "10 & under", Distance: "100", Stroke: "Backstroke", Course: "SCY", Time: "1:18.49"},
+		{Name: "B", Gender: "girls", AgeGroup: "11-12", Distance: "50", Stroke: "Freestyle", Course: "LCM", Time: "35.69"},
+		{Name: "BB", Gender: "girls", AgeGroup: "11-12", Distance: "50", Stroke: "Freestyle", Course: "LCM", Time: "32.89"},
+		{Name: "A", Gender: "girls", AgeGroup: "11-12", Distance: "50", Stroke: "Freestyle", Course: "LCM", Time: "30.29"},
+		// the page banner between the rows isn't a header
+		{Name: "B", Gender: "girls", AgeGroup: "11-12", Distance: "100", Stroke: "Freestyle", Course: "LCM", Time: "1:18.49"},
+		{Name: "BB", Gender: "girls", AgeGroup: "11-12", Distance: "100", Stroke: "Freestyle", Course: "LCM", Time: "1:12.29"},
+		{Name: "A", Gender: "girls", AgeGroup: "11-12", Distance: "100", Stroke: "Freestyle", Course: "LCM", Time: "1:06.09"},
+	}
+	if diff := cmp.Diff(expected, standards.Standards); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseStandardsEvent(t *testing.T) {
+	tests := []struct {
+		line     string
+		distance string
+		stroke   string
+		course   string
+	}{
+		{"50 FR SCY", "50", "Freestyle", "SCY"},
+		{"100 Yard Freestyle", "100", "Freestyle", "SCY"},
+		{"200 LC Meter IM", "200", "IM", "LCM"},
+		{"100 FL LCM", "100", "Butterfly", "LCM"},
+		{"200 BR", "200", "Breaststroke", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			distance, stroke, course, err := parseStandardsEvent(tt.line)
+			if err != nil {
+				t.Fatalf("error: %s", err)
+			}
+			if distance != tt.distance || stroke != tt.stroke || course != tt.course {
+				t.Fatalf("got (%s, %s, %s), expected (%s, %s, %s)", distance, stroke, course, tt.distance, tt.stroke, tt.course)
+			}
+		})
+	}
+}
+
+func TestStandardsAchieved(t *testing.T) {
+	standards := Standards{
+		Standards: []*TimeStandard{
+			{Name: "B", Gender: "girls", AgeGroup: "10 & under", Distance: "50", Stroke: "Freestyle", Course: "SCY", Time: "36.19"},
+			{Name: "A", Gender: "girls", AgeGroup: "10 & under", Distance: "50", Stroke: "Freestyle", Course: "SCY", Time: "30.59"},
+			{Name: "B", Gender: "girls", AgeGroup: "10 & under", Distance: "50", Stroke: "Freestyle", Course: "LCM", Time: "40.19"},
+		},
+	}
+	swimmerTime := &SwimmerTime{
+		Event: &Event{Gender: "girls", AgeGroup: "9-10", Distance: "50 Yard", Stroke: "Freestyle"},
+		Age:   "9",
+		Time:  "33.12",
+	}
+	achieved := standards.Achieved(swimmerTime)
+	if diff := cmp.Diff([]string{"B"}, achieved); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseStandardsTextUnknownNames(t *testing.T) {
+	text := `Girls 13-14
+SILVER GOLD
+100 Yard Freestyle 1:02.19 58.89
+`
+	standards, err := parseStandardsText(bytes.NewBufferString(text))
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	if len(standards.ParseErrors) > 0 {
+		t.Fatalf("parse errors: %+v", standards.ParseErrors[0])
+	}
+	names := []string{}
+	for _, standard := range standards.Standards {
+		names = append(names, standard.Name)
+	}
+	if diff := cmp.Diff([]string{"SILVER", "GOLD"}, names); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}
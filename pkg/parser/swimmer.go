@@ -2,28 +2,40 @@ package parser
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 )
 
-func processLine(line string, fileType string) (*SwimmerTime, error) {
+func processLine(line string, fileType string, schema *columnSchema) (*SwimmerTime, error) {
 	switch fileType {
 	case FILETYPE_TYPE2:
 		return processLineType2(line)
 	default:
-		return processLineType1(line)
+		if !schema.hasDefaultIdentity() {
+			return processLineColumns(line, schema)
+		}
+		return processLineType1(line, schema)
 	}
 }
 
 func processLineType2(line string) (*SwimmerTime, error) {
 	swimmer := &SwimmerTime{}
+	var err error
+	// line: 1 Lastname, Firstname 6 PFP 18.14 x18.39
+	line, exhibition := stripExhibitionMarker(line)
 	// line: 1 Lastname, Firstname 6 PFP 18.14 18.39
 	index1 := strings.Index(line, " ")
 	if index1 == -1 {
 		return swimmer, fmt.Errorf("couldn't determine place")
 	}
-	swimmer.Place = line[0:index1]
+	swimmer.Place, err = parsePlace(line[0:index1])
+	if err != nil {
+		return swimmer, fmt.Errorf("couldn't determine place: %s", err)
+	}
+	swimmer.Place.Exhibition = swimmer.Place.Exhibition || exhibition
 	line = line[index1+1:]
 	// line: Lastname, Firstname 6 PFP 18.14 18.39
 	index2 := stringAgeIndex(line)
@@ -34,22 +46,32 @@ func processLineType2(line string) (*SwimmerTime, error) {
 	line = line[index2:]
 	// line: 6 PFP 18.14 18.39
 	index3 := strings.Index(line, " ")
-	if index1 == -1 {
+	if index3 == -1 {
 		return swimmer, fmt.Errorf("couldn't determine age")
 	}
 	swimmer.Age = line[0:index3]
 	line = line[index3+1:]
 	// line: PFP 18.14 18.39
 	index4 := strings.Index(line, " ")
-	if index1 == -1 {
+	if index4 == -1 {
 		return swimmer, fmt.Errorf("couldn't determine team name")
 	}
 	swimmer.TeamName = line[0:index4]
 	line = line[index4+1:]
+	err = processLineType2Times(swimmer, line)
+	if err != nil {
+		return swimmer, err
+	}
+	return swimmer, nil
+}
+
+// processLineType2Times parses the times after the team, the points and the achievements
+// line: 18.14 18.39 9 INV
+func processLineType2Times(swimmer *SwimmerTime, line string) error {
 	// line: 18.14 18.39
 	index5 := strings.Index(line, " ")
-	if index1 == -1 {
-		return swimmer, fmt.Errorf("couldn't determine seed time")
+	if index5 == -1 {
+		return fmt.Errorf("couldn't determine seed time")
 	}
 	swimmer.SeedTime = line[0:index5]
 	line = line[index5+1:]
@@ -67,19 +89,19 @@ func processLineType2(line string) (*SwimmerTime, error) {
 	// line: 9 INV
 	if line != "" {
 		index7 := strings.Index(line, " ")
-		if index7 == -1 { // line: SWT    or   line: 9
-			if !isNumeric(line) {
+		if index7 == -1 { // line: SWT    or   line: 9    or   line: 8.5
+			if !isDecimal(line) {
 				swimmer.Achievements = line
 			} else {
-				swimmer.Points = line
+				swimmer.setPoints(line)
 			}
 			line = ""
 		} else {
-			if !isNumeric(line[0:index7]) { // line: INV Somethingelse
+			if !isDecimal(line[0:index7]) { // line: INV Somethingelse
 				swimmer.Achievements = line[0:index7]
 				line = line[index7+1:]
 			} else { // line: 9 INV
-				swimmer.Points = line[0:index7]
+				swimmer.setPoints(line[0:index7])
 				line = line[index7+1:]
 			}
 		}
@@ -92,20 +114,27 @@ func processLineType2(line string) (*SwimmerTime, error) {
 
 	err := checkResidual(swimmer.SeedTime + " " + swimmer.Time + " " + line)
 	if err != nil {
-		return swimmer, fmt.Errorf("residual information found: '%s'", err)
+		return fmt.Errorf("residual information found: '%s'", err)
 	}
 
-	return swimmer, nil
+	return nil
 }
 
-func processLineType1(line string) (*SwimmerTime, error) {
+func processLineType1(line string, schema *columnSchema) (*SwimmerTime, error) {
 	swimmer := &SwimmerTime{}
+	var err error
+	// line: 1 Lastname, Firstname  14 Lynchburg YMCA 2:14.96 x2:16.72 AG 9
+	line, exhibition := stripExhibitionMarker(line)
 	// line: 1 Lastname, Firstname  14 Lynchburg YMCA 2:14.96 2:16.72 AG 9
 	index1 := strings.Index(line, " ")
 	if index1 == -1 {
 		return swimmer, fmt.Errorf("couldn't determine place")
 	}
-	swimmer.Place = line[0:index1]
+	swimmer.Place, err = parsePlace(line[0:index1])
+	if err != nil {
+		return swimmer, fmt.Errorf("couldn't determine place: %s", err)
+	}
+	swimmer.Place.Exhibition = swimmer.Place.Exhibition || exhibition
 	line = line[index1+1:]
 	// line: Lastname, Firstname  14 Lynchburg YMCA 2:14.96 2:16.72 AG 9
 	index2 := strings.Index(line, "   ")
@@ -135,29 +164,54 @@ func processLineType1(line string) (*SwimmerTime, error) {
 	swimmer.Age = line[0:index3]
 	line = line[index3+1:]
 	// line: Lynchburg YMCA 2:14.96 2:16.72 AG 9
-	indexAfterTeamName := 0
+	err = processTimeColumns(swimmer, line, schema)
+	if err != nil {
+		return swimmer, err
+	}
+	return swimmer, nil
+}
+
+// processTimeColumns parses the team name in front of the times, the times and everything after the times
+// line: Lynchburg YMCA 2:14.96 2:16.72 AG 9
+func processTimeColumns(swimmer *SwimmerTime, line string, schema *columnSchema) error {
 	var err error
+	// line: Lynchburg YMCA 2:20.31 2:22.04 12.50
+	line, points := splitTrailingPoints(line, schema.timeColumnCount())
+	if points != "" {
+		swimmer.setPoints(points)
+	}
+	// line: Lynchburg YMCA 1:00.00 59.00 58.50 20
+	line, prelimTime, prelimOnly := schema.splitPrelimTime(line)
+	swimmer.PrelimTime = prelimTime
+	// line: Lynchburg YMCA 2:14.96 2:16.72 AG 9
+	indexAfterTeamName := 0
 	indexAfterTeamName, swimmer.SeedTime, swimmer.Time, err = processTimes(line)
 	if err != nil {
-		fmt.Printf("Line: %s\n", line)
-		return swimmer, fmt.Errorf("process time error: %s", err)
-	}
-	teamName := strings.TrimSpace(line[0:indexAfterTeamName])
-	if index := strings.Index(teamName, "-"); index != -1 {
-		swimmer.TeamLSC = teamName[index+1:]
-		swimmer.TeamName = teamName[0:index]
-	} else {
-		swimmer.TeamName = teamName
+		return fmt.Errorf("process time error: %s", err)
+	}
+	if prelimOnly {
+		swimmer.PrelimTime = swimmer.Time
+	} else if !schema.hasTimeColumn(TIME_COLUMN_SEED) && swimmer.SeedTime != "" {
+		// line: Lynchburg YMCA 59.00 58.50 (prelims and finals, without seed)
+		swimmer.PrelimTime = swimmer.SeedTime
+		swimmer.SeedTime = ""
+	}
+	if teamName := strings.TrimSpace(line[0:indexAfterTeamName]); teamName != "" {
+		if swimmer.TeamName != "" {
+			return fmt.Errorf("residual information found in front of the times: '%s'", teamName)
+		}
+		setTeamName(swimmer, teamName)
 	}
 	line = line[indexAfterTeamName:]
 	// Extract points
 	// line: 2:14.96 2:16.72 AG 9
 	// line: 2:14.96 2:16.72
 	// line:   2:16.72
-	if !strings.HasSuffix(line, "  ") {
+	// line: 2:14.96 2:16.72 AG 8.5
+	if !strings.HasSuffix(line, "  ") && points == "" {
 		pointsIndex := strings.LastIndex(line, " ")
-		if isNumeric(line[pointsIndex+1:]) {
-			swimmer.Points = line[pointsIndex+1:]
+		if pointsIndex != -1 && isDecimal(line[pointsIndex+1:]) && !isTime(line[pointsIndex+1:]) {
+			swimmer.setPoints(line[pointsIndex+1:])
 			line = line[0:pointsIndex]
 		}
 	}
@@ -170,20 +224,20 @@ func processLineType1(line string) (*SwimmerTime, error) {
 		}
 		err = checkResidual(residual)
 		if err != nil {
-			return swimmer, fmt.Errorf("residual information found: '%s'", err)
+			return fmt.Errorf("residual information found: '%s'", err)
 		}
 		err = validateSwimmer(swimmer)
 		if err != nil {
-			return swimmer, fmt.Errorf("invalid swimmer data: '%s'", err)
+			return fmt.Errorf("invalid swimmer data: '%s'", err)
 		}
-		return swimmer, nil
+		return nil
 	}
 
 	// Extract qualifying standards
 	// line: "10:49.69 Y 9:43.85 TAGS  "
 	line = strings.TrimSpace(line) // remove unnecessary spacing
 	qualifyingStandardsIndex := strings.LastIndex(line, " ")
-	if !timesRegex.MatchString(line[qualifyingStandardsIndex+1:]) {
+	if qualifyingStandardsIndex != -1 && !timesRegex.MatchString(line[qualifyingStandardsIndex+1:]) {
 		if line[qualifyingStandardsIndex+1:] != "DQ" && line[qualifyingStandardsIndex+1:] != "NS" && line[qualifyingStandardsIndex+1:] != "DNF" && line[qualifyingStandardsIndex+1:] != "DFS" && line[qualifyingStandardsIndex+1:] != "q" {
 			swimmer.QualifyingStandards = line[qualifyingStandardsIndex+1:]
 			line = line[0:qualifyingStandardsIndex]
@@ -191,9 +245,6 @@ func processLineType1(line string) (*SwimmerTime, error) {
 	}
 	// line: 10:43.41 Y 9:29.11
 	seedTagIndex := strings.Index(line, " ")
-	if index1 == -1 {
-		return swimmer, fmt.Errorf("couldn't determine seed time tag")
-	}
 	if strings.HasPrefix(line[seedTagIndex+1:], "Y ") || strings.HasPrefix(line[seedTagIndex+1:], "S ") || strings.HasPrefix(line[seedTagIndex+1:], "L ") {
 		swimmer.SeedTimeTag = line[seedTagIndex+1 : seedTagIndex+2]
 		line = line[0:seedTagIndex+1] + line[seedTagIndex+3:]
@@ -211,20 +262,20 @@ func processLineType1(line string) (*SwimmerTime, error) {
 
 	err = checkResidual(line)
 	if err != nil {
-		return swimmer, fmt.Errorf("residual information found: '%s'", err)
+		return fmt.Errorf("residual information found: '%s'", err)
 	}
 
 	err = validateSwimmer(swimmer)
 	if err != nil {
-		return swimmer, fmt.Errorf("invalid swimmer data: '%s'", err)
+		return fmt.Errorf("invalid swimmer data: '%s'", err)
 	}
 
-	return swimmer, nil
+	return nil
 }
 
 func validateSwimmer(swimmer *SwimmerTime) error {
-	if swimmer.Points != "" && !isNumeric(swimmer.Points) {
-		return fmt.Errorf("points is not numeric")
+	if swimmer.Points < 0 {
+		return fmt.Errorf("points is negative")
 	}
 	if swimmer.Time != "" && !timesRegex.MatchString(swimmer.Time) {
 		if !isValidTimeCode(swimmer.Time) {
@@ -306,6 +357,36 @@ func stringAgeIndex(s string) int {
 	return -1
 }
 
+var decimalRegex = regexp.MustCompile(`^\d+(?:\.\d+)?$`)
+
+// isDecimal checks for points, which can be split by a tie (8.5, 12.50)
+func isDecimal(s string) bool {
+	return decimalRegex.MatchString(s)
+}
+
+// setPoints sets the points printed on the line
+func (s *SwimmerTime) setPoints(points string) error {
+	var err error
+	s.Points, err = parsePoints(points)
+	s.PointsPrinted = err == nil
+	return err
+}
+
+// setPoints sets the points printed on the line
+func (r *RelayTime) setPoints(points string) error {
+	var err error
+	r.Points, err = parsePoints(points)
+	r.PointsPrinted = err == nil
+	return err
+}
+
+func parsePoints(s string) (float64, error) {
+	if !isDecimal(s) {
+		return 0, fmt.Errorf("points is not numeric: '%s'", s)
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
 func isNumeric(s string) bool {
 	if len(s) == 0 {
 		return false
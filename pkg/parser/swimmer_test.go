@@ -29,6 +29,11 @@ func TestProcessLineType1(t *testing.T) {
 		"1 Lastname, Firstname  14 Nation's Capital Swim Club 21.27 21.26 # q",
 		"1 Lastname, Firstname J  12 TFA-NT 1:58.97",
 		"1 Lastname, Firstname T  10 LAC-NT 28.03   20",
+		"*3 Lastname, Firstname  14 Lynchburg YMCA 2:20.31 2:22.04 7",
+		"12 Lastname, Firstname  14 Lynchburg YMCA 2:20.31 x2:22.04",
+		"*3 Lastname, Firstname  14 Lynchburg YMCA 2:20.31 2:22.04 6.5",
+		"*3 Lastname, Firstname  14 Lynchburg YMCA 2:20.31 2:22.04 AG 12.50",
+		"4 Lastname, Firstname   6 Lynchburg YMCA 13.10 12.50",
 	}
 	expected := []SwimmerTime{
 		{
@@ -38,8 +43,9 @@ func TestProcessLineType1(t *testing.T) {
 			Time:                "9:18.83",
 			SeedTime:            "9:27.94",
 			Age:                 "14",
-			Place:               "1",
-			Points:              "20",
+			Place:               Place{Value: 1},
+			Points:              20,
+			PointsPrinted:       true,
 			QualifyingStandards: "TAGS",
 		},
 		{ // "2 Lastname, Firstname  13 Metroplex Aquatics-NT 9:26.82 9:20.36 TAGS 17",
@@ -49,8 +55,9 @@ func TestProcessLineType1(t *testing.T) {
 			Time:                "9:20.36",
 			SeedTime:            "9:26.82",
 			Age:                 "13",
-			Place:               "2",
-			Points:              "17",
+			Place:               Place{Value: 2},
+			Points:              17,
+			PointsPrinted:       true,
 			QualifyingStandards: "TAGS",
 		},
 		{ // "3 Lastname, Firstname S  14 Alamo Area Aquatic Association-ST 9:30.09 9:24.36 TAGS 16",
@@ -60,8 +67,9 @@ func TestProcessLineType1(t *testing.T) {
 			Time:                "9:24.36",
 			SeedTime:            "9:30.09",
 			Age:                 "14",
-			Place:               "3",
-			Points:              "16",
+			Place:               Place{Value: 3},
+			Points:              16,
+			PointsPrinted:       true,
 			QualifyingStandards: "TAGS",
 		},
 		{ // "2 Lastname, Firstname J  10 Rockwall Aquatic Center of Exc-NT 2:45.55 2:40.93 TAGS 17",
@@ -70,9 +78,10 @@ func TestProcessLineType1(t *testing.T) {
 			TeamLSC:             "NT",
 			Time:                "2:40.93",
 			SeedTime:            "2:45.55",
-			Place:               "2",
+			Place:               Place{Value: 2},
 			Age:                 "10",
-			Points:              "17",
+			Points:              17,
+			PointsPrinted:       true,
 			QualifyingStandards: "TAGS",
 		},
 		{
@@ -81,9 +90,10 @@ func TestProcessLineType1(t *testing.T) {
 			TeamLSC:             "ST",
 			Time:                "2:44.99",
 			SeedTime:            "2:48.71",
-			Place:               "3",
+			Place:               Place{Value: 3},
 			Age:                 "10",
-			Points:              "16",
+			Points:              16,
+			PointsPrinted:       true,
 			QualifyingStandards: "TAGS",
 		},
 		{
@@ -92,50 +102,54 @@ func TestProcessLineType1(t *testing.T) {
 			Time:                "2:16.72",
 			SeedTime:            "2:14.96",
 			Age:                 "14",
-			Place:               "1",
-			Points:              "9",
+			Place:               Place{Value: 1},
+			Points:              9,
+			PointsPrinted:       true,
 			QualifyingStandards: "AG",
 		},
 		{
-			Name:     "Lastname, Firstname",
-			TeamName: "Lynchburg YMCA",
-			Time:     "2:22.04",
-			SeedTime: "2:20.31",
-			Age:      "14",
-			Place:    "2",
-			Points:   "7",
+			Name:          "Lastname, Firstname",
+			TeamName:      "Lynchburg YMCA",
+			Time:          "2:22.04",
+			SeedTime:      "2:20.31",
+			Age:           "14",
+			Place:         Place{Value: 2},
+			Points:        7,
+			PointsPrinted: true,
 		},
 		{
-			Name:     "Lastname, Firstname",
-			TeamName: "Lynchburg YMCA",
-			Time:     "2:24.11",
-			SeedTime: "2:26.59",
-			Age:      "13",
-			Place:    "3",
-			Points:   "6",
+			Name:          "Lastname, Firstname",
+			TeamName:      "Lynchburg YMCA",
+			Time:          "2:24.11",
+			SeedTime:      "2:26.59",
+			Age:           "13",
+			Place:         Place{Value: 3},
+			Points:        6,
+			PointsPrinted: true,
 		},
 		{
 			Name:     "Lastname, Firstname",
 			TeamName: "Lynchburg YMCA",
 			Time:     "DQ",
-			Place:    "---",
+			Place:    Place{Unranked: true},
 			SeedTime: "NT",
 			Age:      "9",
 		},
 		{
-			Name:     "Lastname, Firstname",
-			TeamName: "Lynchburg YMCA",
-			Time:     "33.49",
-			Place:    "7",
-			SeedTime: "NT",
-			Age:      "5",
-			Points:   "2",
+			Name:          "Lastname, Firstname",
+			TeamName:      "Lynchburg YMCA",
+			Time:          "33.49",
+			Place:         Place{Value: 7},
+			SeedTime:      "NT",
+			Age:           "5",
+			Points:        2,
+			PointsPrinted: true,
 		},
 		{
 			Name:     "Lastname, Firstname",
 			TeamName: "Lynchburg YMCA",
 			Time:     "DQ",
-			Place:    "---",
+			Place:    Place{Unranked: true},
 			SeedTime: "30.88",
 			Age:      "8",
 		},
@@ -144,10 +158,11 @@ func TestProcessLineType1(t *testing.T) {
 			TeamName:            "Mansfield Aquatic Club",
 			TeamLSC:             "NT",
 			Time:                "9:29.11",
-			Place:               "6",
+			Place:               Place{Value: 6},
 			SeedTime:            "10:43.41",
 			SeedTimeTag:         "Y",
-			Points:              "13",
+			Points:              13,
+			PointsPrinted:       true,
 			QualifyingStandards: "TAGS",
 			Age:                 "14",
 		},
@@ -156,10 +171,10 @@ func TestProcessLineType1(t *testing.T) {
 			TeamName:            "The Woodlands Swim Team",
 			TeamLSC:             "GU",
 			Time:                "9:43.85",
-			Place:               "17",
+			Place:               Place{Value: 17},
 			SeedTime:            "10:49.69",
 			SeedTimeTag:         "Y",
-			Points:              "",
+			Points:              0,
 			QualifyingStandards: "TAGS",
 			Age:                 "13",
 		},
@@ -168,7 +183,7 @@ func TestProcessLineType1(t *testing.T) {
 			TeamName: "SJAC",
 			TeamLSC:  "MA",
 			Time:     "1:09.33",
-			Place:    "1",
+			Place:    Place{Value: 1},
 			Age:      "12",
 		},
 		{ // --- Lastname, Firstname 14 Nation's Capital Swim Club DFS
@@ -176,7 +191,7 @@ func TestProcessLineType1(t *testing.T) {
 			TeamName: "Nation's Capital Swim Club",
 			TeamLSC:  "",
 			Time:     "DFS",
-			Place:    "---",
+			Place:    Place{Unranked: true},
 			Age:      "14",
 		},
 		{ // --- 36 Lastname, Firstname  17 Nation's Capital Swim Club J22.27
@@ -184,7 +199,7 @@ func TestProcessLineType1(t *testing.T) {
 			TeamName: "Nation's Capital Swim Club",
 			TeamLSC:  "",
 			Time:     "J22.27",
-			Place:    "36",
+			Place:    Place{Value: 36},
 			Age:      "17",
 		},
 		{ // "--- Lastname, Firstname  17 Occoquan Swimming DQ"
@@ -192,7 +207,7 @@ func TestProcessLineType1(t *testing.T) {
 			TeamName: "Occoquan Swimming",
 			TeamLSC:  "",
 			Time:     "DQ",
-			Place:    "---",
+			Place:    Place{Unranked: true},
 			Age:      "17",
 		},
 		{ // "1 Lastname, Firstname  14 Nation's Capital Swim Club 21.27 21.26 # q",
@@ -201,7 +216,7 @@ func TestProcessLineType1(t *testing.T) {
 			TeamLSC:   "",
 			SeedTime:  "21.27",
 			Time:      "21.26",
-			Place:     "1",
+			Place:     Place{Value: 1},
 			Qualified: true,
 			NewRecord: true,
 			Age:       "14",
@@ -211,22 +226,70 @@ func TestProcessLineType1(t *testing.T) {
 			TeamName: "TFA",
 			TeamLSC:  "NT",
 			Time:     "1:58.97",
-			Place:    "1",
+			Place:    Place{Value: 1},
 			Age:      "12",
 		},
 		{ // "1 Lastname, Firstname T  10 LAC-NT 28.03   20",
-			Name:     "Lastname, Firstname T",
-			TeamName: "LAC",
-			TeamLSC:  "NT",
-			Time:     "28.03",
-			Place:    "1",
-			Age:      "10",
-			Points:   "20",
+			Name:          "Lastname, Firstname T",
+			TeamName:      "LAC",
+			TeamLSC:       "NT",
+			Time:          "28.03",
+			Place:         Place{Value: 1},
+			Age:           "10",
+			Points:        20,
+			PointsPrinted: true,
+		},
+		{ // "*3 Lastname, Firstname  14 Lynchburg YMCA 2:20.31 2:22.04 7",
+			Name:          "Lastname, Firstname",
+			TeamName:      "Lynchburg YMCA",
+			Time:          "2:22.04",
+			SeedTime:      "2:20.31",
+			Age:           "14",
+			Place:         Place{Value: 3, Tie: true},
+			Points:        7,
+			PointsPrinted: true,
+		},
+		{ // "12 Lastname, Firstname  14 Lynchburg YMCA 2:20.31 x2:22.04",
+			Name:     "Lastname, Firstname",
+			TeamName: "Lynchburg YMCA",
+			Time:     "2:22.04",
+			SeedTime: "2:20.31",
+			Age:      "14",
+			Place:    Place{Value: 12, Exhibition: true},
+		},
+		{ // "*3 Lastname, Firstname  14 Lynchburg YMCA 2:20.31 2:22.04 6.5",
+			Name:          "Lastname, Firstname",
+			TeamName:      "Lynchburg YMCA",
+			Time:          "2:22.04",
+			SeedTime:      "2:20.31",
+			Age:           "14",
+			Place:         Place{Value: 3, Tie: true},
+			Points:        6.5,
+			PointsPrinted: true,
+		},
+		{ // "*3 Lastname, Firstname  14 Lynchburg YMCA 2:20.31 2:22.04 AG 12.50",
+			Name:                "Lastname, Firstname",
+			TeamName:            "Lynchburg YMCA",
+			Time:                "2:22.04",
+			SeedTime:            "2:20.31",
+			Age:                 "14",
+			Place:               Place{Value: 3, Tie: true},
+			Points:              12.5,
+			PointsPrinted:       true,
+			QualifyingStandards: "AG",
+		},
+		{ // "4 Lastname, Firstname   6 Lynchburg YMCA 13.10 12.50",
+			Name:     "Lastname, Firstname",
+			TeamName: "Lynchburg YMCA",
+			Time:     "12.50",
+			SeedTime: "13.10",
+			Age:      "6",
+			Place:    Place{Value: 4},
 		},
 	}
 
 	for k, line := range lines {
-		parsed, err := processLineType1(line)
+		parsed, err := processLineType1(line, nil)
 		if err != nil {
 			t.Fatalf("error: %s. SwimmerTime: %+v", err, parsed)
 		}
@@ -248,6 +311,9 @@ func TestProcessLineType2(t *testing.T) {
 		"1 Lastname, Firstname 6 PFP 18.14 20.06 9 INV",
 		"1 Lastname, Firstname 6 PFP 18.14 20.06 9",
 		"1 Lastname, Firstname 6 PFP 18.14 20.06 INV",
+		"*2 Lastname, Firstname 6 PFP 18.14 18.39",
+		"5 Lastname, Firstname 6 PFP 18.14 x18.39",
+		"*1 Lastname, Firstname 6 PFP 18.14 20.06 8.5 INV",
 	}
 	expected := []SwimmerTime{
 		{
@@ -256,7 +322,7 @@ func TestProcessLineType2(t *testing.T) {
 			SeedTime: "18.14",
 			Time:     "18.39",
 			Age:      "6",
-			Place:    "1",
+			Place:    Place{Value: 1},
 		},
 		{
 			Name:     "Lastname, Firstname",
@@ -264,7 +330,7 @@ func TestProcessLineType2(t *testing.T) {
 			SeedTime: "19.95",
 			Time:     "20.97",
 			Age:      "6",
-			Place:    "2",
+			Place:    Place{Value: 2},
 		},
 		{
 			Name:     "Lastname, Firstname",
@@ -272,7 +338,7 @@ func TestProcessLineType2(t *testing.T) {
 			SeedTime: "22.47",
 			Time:     "22.13",
 			Age:      "6",
-			Place:    "3",
+			Place:    Place{Value: 3},
 		},
 		{ // 9 Lastnamé, Firstnamë 7 BC25 24.26 23.63"
 			Name:     "Lastnamé, Firstnamë",
@@ -280,7 +346,7 @@ func TestProcessLineType2(t *testing.T) {
 			SeedTime: "24.26",
 			Time:     "23.63",
 			Age:      "7",
-			Place:    "9",
+			Place:    Place{Value: 9},
 		},
 		{ // "11 Lastname, Firstname 12 SWT 34.57 34.08 SWT",
 			Name:         "Lastname, Firstname",
@@ -288,28 +354,30 @@ func TestProcessLineType2(t *testing.T) {
 			SeedTime:     "34.57",
 			Time:         "34.08",
 			Age:          "12",
-			Place:        "11",
+			Place:        Place{Value: 11},
 			Achievements: "SWT",
 		},
 		{ // 1 Lastname, Firstname 6 PFP 18.14 20.06 9 INV
-			Name:         "Lastname, Firstname",
-			TeamName:     "PFP",
-			SeedTime:     "18.14",
-			Time:         "20.06",
-			Age:          "6",
-			Place:        "1",
-			Points:       "9",
-			Achievements: "INV",
+			Name:          "Lastname, Firstname",
+			TeamName:      "PFP",
+			SeedTime:      "18.14",
+			Time:          "20.06",
+			Age:           "6",
+			Place:         Place{Value: 1},
+			Points:        9,
+			PointsPrinted: true,
+			Achievements:  "INV",
 		},
 		{ // 1 Lastname, Firstname 6 PFP 18.14 20.06 9
-			Name:         "Lastname, Firstname",
-			TeamName:     "PFP",
-			SeedTime:     "18.14",
-			Time:         "20.06",
-			Age:          "6",
-			Place:        "1",
-			Points:       "9",
-			Achievements: "",
+			Name:          "Lastname, Firstname",
+			TeamName:      "PFP",
+			SeedTime:      "18.14",
+			Time:          "20.06",
+			Age:           "6",
+			Place:         Place{Value: 1},
+			Points:        9,
+			PointsPrinted: true,
+			Achievements:  "",
 		},
 		{ // 1 Lastname, Firstname 6 PFP 18.14 20.06 INV
 			Name:         "Lastname, Firstname",
@@ -317,10 +385,37 @@ func TestProcessLineType2(t *testing.T) {
 			SeedTime:     "18.14",
 			Time:         "20.06",
 			Age:          "6",
-			Place:        "1",
-			Points:       "",
+			Place:        Place{Value: 1},
+			Points:       0,
 			Achievements: "INV",
 		},
+		{ // *2 Lastname, Firstname 6 PFP 18.14 18.39
+			Name:     "Lastname, Firstname",
+			TeamName: "PFP",
+			SeedTime: "18.14",
+			Time:     "18.39",
+			Age:      "6",
+			Place:    Place{Value: 2, Tie: true},
+		},
+		{ // 5 Lastname, Firstname 6 PFP 18.14 x18.39
+			Name:     "Lastname, Firstname",
+			TeamName: "PFP",
+			SeedTime: "18.14",
+			Time:     "18.39",
+			Age:      "6",
+			Place:    Place{Value: 5, Exhibition: true},
+		},
+		{ // *1 Lastname, Firstname 6 PFP 18.14 20.06 8.5 INV
+			Name:          "Lastname, Firstname",
+			TeamName:      "PFP",
+			SeedTime:      "18.14",
+			Time:          "20.06",
+			Age:           "6",
+			Place:         Place{Value: 1, Tie: true},
+			Points:        8.5,
+			PointsPrinted: true,
+			Achievements:  "INV",
+		},
 	}
 
 	for k, line := range lines {
@@ -354,12 +449,21 @@ func TestProcessLineType2(t *testing.T) {
 			t.Fatalf("Achievements: got: '%s', expected: '%s'", parsed.Achievements, expected[k].Achievements)
 		}
 		if parsed.Points != expected[k].Points {
-			t.Fatalf("Points: got: '%s', expected: '%s'", parsed.Points, expected[k].Points)
+			t.Fatalf("Points: got: '%v', expected: '%v'", parsed.Points, expected[k].Points)
 		}
 	}
 
 }
 
+func TestIsDecimal(t *testing.T) {
+	tests := map[string]bool{"9": true, "8.5": true, "12.50": true, "": false, "1:12.50": false, "INV": false, "8.": false}
+	for input, expected := range tests {
+		if got := isDecimal(input); got != expected {
+			t.Errorf("isDecimal(%q) = %v; want %v", input, got, expected)
+		}
+	}
+}
+
 func TestStringAgeIndex(t *testing.T) {
 	tests := []struct {
 		input    string
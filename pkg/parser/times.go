@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -14,6 +15,7 @@ var timesDQRegex = regexp.MustCompile(`(?:\d{1,2}:)?\d{2}\.\d{2}(?: [YLS])? DQ`)
 var timesNSRegex = regexp.MustCompile(`(?:\d{1,2}:)?\d{2}\.\d{2}(?: [YLS])? NS`)
 var timesDFSRegex = regexp.MustCompile(`(?:\d{1,2}:)?\d{2}\.\d{2}(?: [YLS])? DFS`)
 var splitTimesRegex = regexp.MustCompile(`^(?:\d{1,2}:)?\d{2}\.\d{2}(?:\s+(?:\d{1,2}:)?\d{2}\.\d{2})*$`)
+var exactTimeRegex = regexp.MustCompile(`^(?:(\d{1,2}):)?(\d{1,2})\.(\d{2})$`)
 
 func processTimes(line string) (int, string, string, error) {
 	matchedTimes := timesRegex.FindAllStringIndex(line, -1)
@@ -24,8 +26,7 @@ func processTimes(line string) (int, string, string, error) {
 	if len(matchedTimes) == 0 || len(matchedTimes) == 1 {
 		return getNoTimeCodes(line, len(matchedTimes))
 	}
-	fmt.Printf("more than 2 times? %v\n", matchedTimes)
-	return -1, "", "", fmt.Errorf("not supported (found more than 2 times)")
+	return -1, "", "", fmt.Errorf("not supported (found %d times, expected at most 2)", len(matchedTimes))
 
 }
 func getNoTimeCodes(line string, timesFound int) (int, string, string, error) {
@@ -100,6 +101,50 @@ func getNoTimeCodes(line string, timesFound int) (int, string, string, error) {
 	return -1, "", "", fmt.Errorf("no codes recognized instead of times (too many elements supplied)")
 }
 
+// splitTrailingPoints splits off points that look like a time (12.50) at the end of the line.
+// They can only be points when the line has more times than the columns it has.
+// line: Lynchburg YMCA 2:20.31 2:22.04 12.50
+func splitTrailingPoints(line string, columns int) (string, string) {
+	if strings.HasSuffix(line, "  ") {
+		return line, ""
+	}
+	index := strings.LastIndex(line, " ")
+	last := line[index+1:]
+	if index == -1 || !isTime(last) || len(timesRegex.FindAllString(line, -1)) <= columns {
+		return line, ""
+	}
+	return line[:index], last
+}
+
 func getSplitTimes(line string) []string {
 	return timesRegex.FindAllString(line, -1)
 }
+
+func isTime(s string) bool {
+	return exactTimeRegex.MatchString(s)
+}
+
+// timeToHundredths converts a time string (1:05.32, 28.03, J22.27) to hundredths of a second
+func timeToHundredths(s string) (int, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "J")
+	match := exactTimeRegex.FindStringSubmatch(s)
+	if match == nil {
+		return 0, fmt.Errorf("not a time: '%s'", s)
+	}
+	minutes := 0
+	if match[1] != "" {
+		minutes, _ = strconv.Atoi(match[1])
+	}
+	seconds, _ := strconv.Atoi(match[2])
+	hundredths, _ := strconv.Atoi(match[3])
+	return minutes*6000 + seconds*100 + hundredths, nil
+}
+
+func formatHundredths(hundredths int) string {
+	minutes := hundredths / 6000
+	seconds := (hundredths % 6000) / 100
+	if minutes > 0 {
+		return fmt.Sprintf("%d:%02d.%02d", minutes, seconds, hundredths%100)
+	}
+	return fmt.Sprintf("%02d.%02d", seconds, hundredths%100)
+}
@@ -0,0 +1,224 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+const (
+	TRACE_LINE   = "line"   // a line of the document: the value is the line, the message the state of the parser
+	TRACE_RULE   = "rule"   // a rule matched the line
+	TRACE_FIELD  = "field"  // a field extracted from the line
+	TRACE_REJECT = "reject" // the line couldn't be parsed: the message is the reason
+	// a test on the line: the rule is the name of the test, the value true or false
+	TRACE_CLASSIFIER = "classifier"
+)
+
+const (
+	RULE_EVENT              = "event"
+	RULE_EVENT_CONTINUATION = "event continuation"
+	RULE_INDIVIDUAL_HEADER  = "individual header"
+	RULE_RELAY_HEADER       = "relay header"
+	RULE_INDIVIDUAL_TIME    = "individual time"
+	RULE_SPLIT_TIMES        = "split times"
+	RULE_RELAY_TIME         = "relay time"
+	RULE_RELAY_SWIMMERS     = "relay swimmers"
+	RULE_ROUND              = "round"
+	RULE_HEAT               = "heat"
+	RULE_SWIM_OFF_REQUIRED  = "swim-off required"
+	RULE_QUALIFYING_TIMES   = "qualifying times"
+	RULE_BREAK              = "page or column break"
+	RULE_RESUME             = "resume after break"
+	RULE_TEAM_VOCABULARY    = "team vocabulary"
+)
+
+// Tracer receives the diagnostics of the parser, line by line
+type Tracer interface {
+	Trace(event TraceEvent)
+}
+
+type TraceEvent struct {
+	Kind       string `json:"kind"`
+	LineNumber int    `json:"lineNumber"`
+	Rule       string `json:"rule,omitempty"`
+	Field      string `json:"field,omitempty"`
+	Value      string `json:"value,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// SlogTracer writes the trace events to a structured logger: rejected lines as warnings, the other events at debug level
+func SlogTracer(logger *slog.Logger) Tracer {
+	return &slogTracer{logger: logger}
+}
+
+type slogTracer struct {
+	logger *slog.Logger
+}
+
+func (s *slogTracer) Trace(event TraceEvent) {
+	level := slog.LevelDebug
+	if event.Kind == TRACE_REJECT {
+		level = slog.LevelWarn
+	}
+	attrs := []slog.Attr{slog.Int("lineNumber", event.LineNumber)}
+	if event.Rule != "" {
+		attrs = append(attrs, slog.String("rule", event.Rule))
+	}
+	if event.Field != "" {
+		attrs = append(attrs, slog.String("field", event.Field))
+	}
+	if event.Value != "" {
+		attrs = append(attrs, slog.String("value", event.Value))
+	}
+	if event.Message != "" {
+		attrs = append(attrs, slog.String("message", event.Message))
+	}
+	s.logger.LogAttrs(context.Background(), level, "parser "+event.Kind, attrs...)
+}
+
+// traceFields returns a field event for every field of a record (event, swimmer time or relay time) that isn't empty.
+// The event and source of the record aren't fields of the line, the swimmers of a relay are on the next lines.
+func traceFields(record interface{}) []TraceEvent {
+	v := reflect.ValueOf(record)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	events := []TraceEvent{}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.Name == "Event" || field.Name == "Source" || field.Name == "Swimmers" || v.Field(i).IsZero() {
+			continue
+		}
+		value := v.Field(i)
+		if value.Kind() == reflect.Map || value.Kind() == reflect.Slice {
+			if value.Len() == 0 {
+				continue
+			}
+		}
+		events = append(events, TraceEvent{
+			Kind:  TRACE_FIELD,
+			Field: field.Name,
+			Value: fmt.Sprint(value.Interface()),
+		})
+	}
+	return events
+}
+
+// Classifier is the result of a test on a line, like isEvent or isvalidTime
+type Classifier struct {
+	Name  string `json:"name"`
+	Match bool   `json:"match"`
+}
+
+// LineTrace is the trace of one line: the state of the parser, the classifiers and the steps of the extraction
+type LineTrace struct {
+	LineNumber int    `json:"lineNumber"`
+	Line       string `json:"line"`
+	// the hash of the line, to refer to the line in the corrections
+	LineHash    string       `json:"lineHash"`
+	State       string       `json:"state"`
+	Classifiers []Classifier `json:"classifiers"`
+	Steps       []TraceEvent `json:"steps"`
+}
+
+// TraceRecorder is a tracer that keeps the trace of every line
+type TraceRecorder struct {
+	Lines []*LineTrace
+}
+
+func (t *TraceRecorder) Trace(event TraceEvent) {
+	if event.Kind == TRACE_LINE || len(t.Lines) == 0 {
+		t.Lines = append(t.Lines, &LineTrace{LineNumber: event.LineNumber, Line: event.Value, LineHash: LineHash(event.Value), State: event.Message})
+		if event.Kind == TRACE_LINE {
+			return
+		}
+	}
+	lineTrace := t.Lines[len(t.Lines)-1]
+	if event.Kind == TRACE_CLASSIFIER {
+		lineTrace.Classifiers = append(lineTrace.Classifiers, Classifier{Name: event.Rule, Match: event.Value == "true"})
+		return
+	}
+	lineTrace.Steps = append(lineTrace.Steps, event)
+}
+
+// Line returns the traces of a line. A line of pdftotext -layout output is a line in every column of the page.
+func (t *TraceRecorder) Line(lineNumber int) []*LineTrace {
+	lines := []*LineTrace{}
+	for _, lineTrace := range t.Lines {
+		if lineTrace.LineNumber == lineNumber {
+			lines = append(lines, lineTrace)
+		}
+	}
+	return lines
+}
+
+// Event returns the trace of the lines of an event: from the event header (or a continuation of the header) up to the next event
+func (t *TraceRecorder) Event(round string) []*LineTrace {
+	lines := []*LineTrace{}
+	current := ""
+	for _, lineTrace := range t.Lines {
+		if lineTrace.hasRule(RULE_EVENT) || lineTrace.hasRule(RULE_EVENT_CONTINUATION) {
+			current = lineTrace.field("Round")
+		}
+		if current == round {
+			lines = append(lines, lineTrace)
+		}
+	}
+	return lines
+}
+
+func (l *LineTrace) hasRule(rule string) bool {
+	for _, step := range l.Steps {
+		if step.Kind == TRACE_RULE && step.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *LineTrace) field(name string) string {
+	for _, step := range l.Steps {
+		if step.Kind == TRACE_FIELD && step.Field == name {
+			return step.Value
+		}
+	}
+	return ""
+}
+
+// String returns the trace as text: the line, the state, the classifiers and every step on a line of its own
+func (l *LineTrace) String() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "line %d (hash %s): %s\n", l.LineNumber, l.LineHash, l.Line)
+	fmt.Fprintf(&out, "  state: %s\n", l.State)
+	if len(l.Classifiers) > 0 {
+		out.WriteString("  classifiers:")
+		for _, classifier := range l.Classifiers {
+			fmt.Fprintf(&out, " %s=%t", classifier.Name, classifier.Match)
+		}
+		out.WriteString("\n")
+	}
+	for _, step := range l.Steps {
+		switch step.Kind {
+		case TRACE_RULE:
+			fmt.Fprintf(&out, "  rule: %s", step.Rule)
+			if step.Message != "" {
+				fmt.Fprintf(&out, " (%s)", step.Message)
+			}
+			out.WriteString("\n")
+		case TRACE_FIELD:
+			fmt.Fprintf(&out, "    %s: %s\n", step.Field, step.Value)
+		case TRACE_REJECT:
+			fmt.Fprintf(&out, "  rejected: %s\n", step.Message)
+		}
+	}
+	if len(l.Steps) == 0 {
+		out.WriteString("  no rule matched\n")
+	}
+	return out.String()
+}
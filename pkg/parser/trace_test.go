@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type recordingTracer struct {
+	events []TraceEvent
+}
+
+func (r *recordingTracer) Trace(event TraceEvent) {
+	r.events = append(r.events, event)
+}
+
+func (r *recordingTracer) find(lineNumber int, kind string) []TraceEvent {
+	events := []TraceEvent{}
+	for _, event := range r.events {
+		if event.LineNumber == lineNumber && event.Kind == kind {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func TestParseLinesTracer(t *testing.T) {
+	input := "Event 1  Girls 10 & Under 50 Yard Freestyle\n" +
+		"Name Age Team Seed Time Finals Time Points\n" +
+		"1 Lastname, Firstname  10 Lynchburg YMCA 33.10 32.54 9\n" +
+		"2 Lastname, Second  9 Heritage Swim 34.00 33.80 31.00 7\n" +
+		"Early take-off swimmer\n"
+	tracer := &recordingTracer{}
+	_, err := parsePDFText(bytes.NewBufferString(input), Options{Tracer: tracer})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+
+	if lines := tracer.find(2, TRACE_LINE); len(lines) != 1 || lines[0].Message != SECTION_INDIVIDUAL {
+		t.Fatalf("got line events %+v, expected the individual state", lines)
+	}
+	if rules := tracer.find(2, TRACE_RULE); len(rules) != 1 || rules[0].Rule != RULE_INDIVIDUAL_TIME {
+		t.Fatalf("got rules %+v, expected %s", rules, RULE_INDIVIDUAL_TIME)
+	}
+	fields := map[string]string{}
+	for _, event := range tracer.find(2, TRACE_FIELD) {
+		fields[event.Field] = event.Value
+	}
+	if fields["Name"] != "Lastname, Firstname" || fields["Time"] != "32.54" || fields["Points"] != "9" {
+		t.Fatalf("got fields %+v", fields)
+	}
+	if rejects := tracer.find(3, TRACE_REJECT); len(rejects) != 1 || rejects[0].Rule != RULE_INDIVIDUAL_TIME {
+		t.Fatalf("got rejects %+v, expected a rejected individual time", rejects)
+	}
+	if rejects := tracer.find(4, TRACE_REJECT); len(rejects) != 1 || rejects[0].Rule != "" {
+		t.Fatalf("got rejects %+v, expected a line without a rule", rejects)
+	}
+}
+
+func TestSlogTracer(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	tracer := SlogTracer(logger)
+	tracer.Trace(TraceEvent{Kind: TRACE_RULE, LineNumber: 3, Rule: RULE_INDIVIDUAL_TIME})
+	tracer.Trace(TraceEvent{Kind: TRACE_REJECT, LineNumber: 3, Rule: RULE_INDIVIDUAL_TIME, Message: "couldn't determine age"})
+	out := buf.String()
+	if strings.Count(out, "\n") != 1 || !strings.Contains(out, `msg="parser reject" lineNumber=3 rule="individual time" message="couldn't determine age"`) {
+		t.Fatalf("unexpected log output: %s", out)
+	}
+}
+
+func TestTraceRecorder(t *testing.T) {
+	input := "Event 1  Girls 10 & Under 50 Yard Freestyle\n" +
+		"Name Age Team Seed Time Finals Time Points\n" +
+		"1 Lastname, Firstname  10 Lynchburg YMCA 33.10 32.54 9\n" +
+		"\n" +
+		"Event 2  Boys 10 & Under 50 Yard Freestyle\n" +
+		"Name Age Team Seed Time Finals Time Points\n" +
+		"1 Lastname, Boy  10 Heritage Swim 31.10 30.10 9\n"
+	recorder := &TraceRecorder{}
+	_, err := parsePDFText(bytes.NewBufferString(input), Options{Tracer: recorder})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(recorder.Lines) != 7 {
+		t.Fatalf("got %d traced lines, expected 7", len(recorder.Lines))
+	}
+	lineTraces := recorder.Line(2)
+	if len(lineTraces) != 1 || lineTraces[0].State != SECTION_INDIVIDUAL {
+		t.Fatalf("got traces %+v, expected line 2 in the individual section", lineTraces)
+	}
+	lineTrace := lineTraces[0]
+	for _, classifier := range lineTrace.Classifiers {
+		if (classifier.Name == "isvalidTime" || classifier.Name == "startsWithPlace") != classifier.Match {
+			t.Fatalf("got %s=%t", classifier.Name, classifier.Match)
+		}
+	}
+	text := lineTrace.String()
+	for _, expected := range []string{"line 2 (hash " + LineHash(lineTrace.Line) + "): 1 Lastname, Firstname", "state: individual", "isvalidTime=true", "rule: individual time", "Name: Lastname, Firstname"} {
+		if !strings.Contains(text, expected) {
+			t.Fatalf("'%s' not found in:\n%s", expected, text)
+		}
+	}
+	event := recorder.Event("2")
+	if len(event) != 3 || event[0].LineNumber != 4 {
+		t.Fatalf("got %d lines for event 2, expected the 3 lines from line 4", len(event))
+	}
+}
@@ -1,15 +1,23 @@
 package parser
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+)
 
 const FILETYPE_TYPE1 = ""
 const FILETYPE_TYPE2 = "SwimTopia Meet Maestro"
+const FILETYPE_SPLASH = "Splash Meet Manager"
 
 type Result struct {
-	Events      []*Event       `json:"events"`
-	Times       []*SwimmerTime `json:"times"`
-	RelayTimes  []*RelayTime   `json:"relayTimes"`
-	ParseErrors []*ParseError  `json:"parseErrors"`
+	Events        []*Event        `json:"events"`
+	Times         []*SwimmerTime  `json:"times"`
+	RelayTimes    []*RelayTime    `json:"relayTimes"`
+	ParseErrors   []*ParseError   `json:"parseErrors"`
+	UnparsedLines []*UnparsedLine `json:"unparsedLines"`
+	Coverage      Coverage        `json:"coverage"`
+	// the detected layout of the document, nil for a format definition
+	Detection *Detection `json:"detection,omitempty"`
 }
 
 type Event struct {
@@ -21,45 +29,87 @@ type Event struct {
 	Stroke          string            `json:"stroke"`
 	Relay           bool              `json:"relay"`
 	QualifyingTimes map[string]string `json:"qualifyingTimes"`
+	Source          *Source           `json:"source,omitempty"`
+	// parsed in lenient mode from a line with an error
+	Uncertain bool `json:"uncertain,omitempty"`
 }
 
 type RelayTime struct {
-	Event               *Event          `json:"event"`
-	Place               string          `json:"place"`
-	TeamName            string          `json:"teamName"`
-	TeamNameShort       string          `json:"teamNameShort,omitempty"`
-	TeamLSC             string          `json:"teamLSC"`
-	RelayEntry          string          `json:"relay"`
-	Time                string          `json:"time"`
-	SeedTime            string          `json:"seedTime"`
-	SeedTimeTag         string          `json:"seedTimeTag"`
-	QualifyingStandards string          `json:"qualifyingStandards"`
-	Points              string          `json:"points"`
+	Event               *Event  `json:"event"`
+	Place               Place   `json:"place"`
+	TeamName            string  `json:"teamName"`
+	TeamNameShort       string  `json:"teamNameShort,omitempty"`
+	TeamLSC             string  `json:"teamLSC"`
+	RelayEntry          string  `json:"relay"`
+	Round               string  `json:"round,omitempty"`
+	Heat                string  `json:"heat,omitempty"`
+	Time                string  `json:"time"`
+	SeedTime            string  `json:"seedTime"`
+	SeedTimeTag         string  `json:"seedTimeTag"`
+	QualifyingStandards string  `json:"qualifyingStandards"`
+	Points              float64 `json:"points"`
+	// the results have a points column for the relay: 0 points printed isn't the same as no points
+	PointsPrinted bool `json:"pointsPrinted,omitempty"`
+	// the points of the time on the World Aquatics points table, not the points of the meet
+	WorldAquaticsPoints int             `json:"worldAquaticsPoints,omitempty"`
 	Achievements        string          `json:"achievements,omitempty"`
 	Swimmers            []*RelaySwimmer `json:"swimmers"`
+	Source              *Source         `json:"source,omitempty"`
+	Uncertain           bool            `json:"uncertain,omitempty"`
 }
+type Place struct {
+	Value      int  `json:"value"`
+	Tie        bool `json:"tie,omitempty"`
+	Exhibition bool `json:"exhibition,omitempty"`
+	Unranked   bool `json:"unranked,omitempty"`
+}
+
 type RelaySwimmer struct {
-	Place string `json:"place"`
-	Name  string `json:"name"`
-	Age   string `json:"age"`
+	Place       string `json:"place"`
+	Name        string `json:"name"`
+	Age         string `json:"age"`
+	YearOfBirth string `json:"yearOfBirth,omitempty"`
+	Uncertain   bool   `json:"uncertain,omitempty"`
 }
 type SwimmerTime struct {
-	Event               *Event   `json:"event"`
-	Place               string   `json:"place"`
-	Age                 string   `json:"age"`
-	Name                string   `json:"name"`
-	TeamName            string   `json:"teamName"`
-	TeamLSC             string   `json:"teamLSC"`
-	Finals              string   `json:"finals"`
-	Time                string   `json:"time"`
-	SeedTime            string   `json:"seedTime"`
-	SeedTimeTag         string   `json:"seedTimeTag"`
-	Points              string   `json:"points"`
+	Event       *Event  `json:"event"`
+	Place       Place   `json:"place"`
+	Age         string  `json:"age"`
+	YearOfBirth string  `json:"yearOfBirth,omitempty"`
+	Grade       string  `json:"grade,omitempty"`
+	Name        string  `json:"name"`
+	TeamName    string  `json:"teamName"`
+	TeamLSC     string  `json:"teamLSC"`
+	Round       string  `json:"round,omitempty"`
+	Heat        string  `json:"heat,omitempty"`
+	Lane        string  `json:"lane,omitempty"`
+	Finals      string  `json:"finals"`
+	FinalsRound string  `json:"finalsRound,omitempty"`
+	PrelimTime  string  `json:"prelimTime,omitempty"`
+	Time        string  `json:"time"`
+	SeedTime    string  `json:"seedTime"`
+	SeedTimeTag string  `json:"seedTimeTag"`
+	Points      float64 `json:"points"`
+	// the results have a points column for the swim: 0 points printed isn't the same as no points
+	PointsPrinted bool `json:"pointsPrinted,omitempty"`
+	// the points of the time on the World Aquatics points table, not the points of the meet
+	WorldAquaticsPoints int      `json:"worldAquaticsPoints,omitempty"`
 	QualifyingStandards string   `json:"qualifyingStandards"`
 	Qualified           bool     `json:"qualified,omitempty"`
 	NewRecord           bool     `json:"newRecord,omitempty"`
 	Achievements        string   `json:"achievements,omitempty"`
 	SplitTimes          []string `json:"splitTimes,omitempty"`
+	Source              *Source  `json:"source,omitempty"`
+	Uncertain           bool     `json:"uncertain,omitempty"`
+}
+
+// Source is the location of a record in the input. The page and column start at 1 (0 when unknown), the line
+// number starts at 0 like the line number of a ParseError. For word positions it's the line built from the words.
+type Source struct {
+	Page       int    `json:"page,omitempty"`
+	Column     int    `json:"column,omitempty"`
+	LineNumber int    `json:"lineNumber"`
+	Line       string `json:"line"`
 }
 
 func (e *Event) String() string {
@@ -73,7 +123,7 @@ func (e *Event) String() string {
 }
 
 func (s *SwimmerTime) String() string {
-	return fmt.Sprintf("Team: '%s', LSC: '%s', Age: '%s', Name: '%s', Time: '%s', Seed Time: '%s', Seed Time Tag: '%s', Points: '%s', Place: '%s', Qualifying Standards: '%s'",
+	return fmt.Sprintf("Team: '%s', LSC: '%s', Age: '%s', Name: '%s', Time: '%s', Seed Time: '%s', Seed Time Tag: '%s', Points: '%v', Place: '%s', Qualifying Standards: '%s'",
 		s.TeamName,
 		s.TeamLSC,
 		s.Age,
@@ -87,10 +137,56 @@ func (s *SwimmerTime) String() string {
 	)
 }
 
+// String is used in the csv files: page 2, column 1, line 134
+func (s *Source) String() string {
+	if s == nil {
+		return ""
+	}
+	out := ""
+	if s.Page > 0 {
+		out += fmt.Sprintf("page %d, ", s.Page)
+	}
+	if s.Column > 0 {
+		out += fmt.Sprintf("column %d, ", s.Column)
+	}
+	out += fmt.Sprintf("line %d", s.LineNumber)
+	if s.Line != "" {
+		// the hash identifies the line in the corrections
+		out += fmt.Sprintf(" (hash %s)", LineHash(s.Line))
+	}
+	return out
+}
+
+func (p Place) String() string {
+	if p.Unranked {
+		return "---"
+	}
+	out := strconv.Itoa(p.Value)
+	if p.Tie {
+		out = "*" + out
+	}
+	if p.Exhibition {
+		out = "x" + out
+	}
+	return out
+}
+
+const (
+	SEVERITY_ERROR   = "error"   // the line couldn't be parsed
+	SEVERITY_WARNING = "warning" // the line was parsed, but the record is incomplete
+	SEVERITY_INFO    = "info"    // the line was parsed, the document is unusual
+)
+
 type ParseError struct {
 	Type               string       `json:"type"`
+	Severity           string       `json:"severity"`
 	LineNumber         int          `json:"lineNumber"`
 	Line               string       `json:"line"`
 	ErrorMessage       string       `json:"errorMessage"`
 	PartialSwimmerTime *SwimmerTime `json:"partialSwimmerTime"`
+	Stack              string       `json:"stack,omitempty"`
+}
+
+func (p *ParseError) Error() string {
+	return fmt.Sprintf("line %d (%s): %s", p.LineNumber, p.Type, p.ErrorMessage)
 }
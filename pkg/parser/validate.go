@@ -0,0 +1,244 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	CHECK_PLACE_ORDER       = "place order"       // a place is printed after a higher place
+	CHECK_PLACE_SKIPPED     = "place skipped"     // a place is missing, not explained by a tie
+	CHECK_TIME_ORDER        = "time order"        // a faster time is placed behind a slower time
+	CHECK_RELAY_LEGS        = "relay legs"        // a relay doesn't have four swimmers
+	CHECK_AGE_GROUP         = "age group"         // the age of a swimmer is outside the age group of the event
+	CHECK_DUPLICATE_SWIMMER = "duplicate swimmer" // a swimmer swims twice in the same event and round
+	CHECK_SPLITS            = "splits"            // the split times don't add up to the final time
+	CHECK_POINTS            = "points"            // the printed points don't match the place
+)
+
+type ValidationOptions struct {
+	// verify the printed points with a scoring table. No points check when nil
+	Scoring *ScoringTable
+}
+
+// ValidationIssue is a result that is inconsistent with the other results of the event: a parse error
+// that slipped through, or an error in the document itself
+type ValidationIssue struct {
+	Check    string  `json:"check"`
+	Severity string  `json:"severity"`
+	Event    *Event  `json:"event"`
+	Round    string  `json:"round,omitempty"`
+	Name     string  `json:"name"`
+	Team     string  `json:"team"`
+	Message  string  `json:"message"`
+	Source   *Source `json:"source,omitempty"`
+}
+
+// validatedSwim is an individual or relay swim of an event, in the order of the results
+type validatedSwim struct {
+	name   string
+	team   string
+	place  Place
+	time   string
+	source *Source
+}
+
+// Validate checks the consistency of the results of every event and round: the order of the places and times,
+// the relay swimmers, the ages, duplicate swimmers, the split times and (with a scoring table) the points
+func Validate(result Result, options ValidationOptions) []*ValidationIssue {
+	issues := []*ValidationIssue{}
+	swims := map[*Event]map[string][]*validatedSwim{}
+	rounds := map[*Event][]string{}
+	addSwim := func(event *Event, round string, swim *validatedSwim) {
+		if swims[event] == nil {
+			swims[event] = map[string][]*validatedSwim{}
+		}
+		if _, ok := swims[event][round]; !ok {
+			rounds[event] = append(rounds[event], round)
+		}
+		swims[event][round] = append(swims[event][round], swim)
+	}
+
+	for _, swimmerTime := range result.Times {
+		if swimmerTime.Event == nil {
+			continue
+		}
+		addSwim(swimmerTime.Event, swimmerTime.Round, &validatedSwim{
+			name:   swimmerTime.Name,
+			team:   swimmerTime.TeamName,
+			place:  swimmerTime.Place,
+			time:   swimmerTime.Time,
+			source: swimmerTime.Source,
+		})
+		issue := &ValidationIssue{Event: swimmerTime.Event, Round: swimmerTime.Round, Name: swimmerTime.Name, Team: swimmerTime.TeamName, Source: swimmerTime.Source}
+		if message := validateAge(swimmerTime.Event, swimmerTime.Age); message != "" {
+			issues = append(issues, issue.with(CHECK_AGE_GROUP, SEVERITY_WARNING, message))
+		}
+		if message := validateSplits(swimmerTime); message != "" {
+			issues = append(issues, issue.with(CHECK_SPLITS, SEVERITY_WARNING, message))
+		}
+	}
+
+	// the relay swimmers are only checked when the document lists them
+	relaySwimmers := false
+	for _, relayTime := range result.RelayTimes {
+		relaySwimmers = relaySwimmers || len(relayTime.Swimmers) > 0
+	}
+	relayLegs := map[string]*RelayTime{}
+	for _, relayTime := range result.RelayTimes {
+		if relayTime.Event == nil {
+			continue
+		}
+		name := strings.TrimSpace(relayTime.TeamName + " " + relayTime.RelayEntry)
+		addSwim(relayTime.Event, relayTime.Round, &validatedSwim{
+			name:   name,
+			team:   relayTeam(relayTime),
+			place:  relayTime.Place,
+			time:   relayTime.Time,
+			source: relayTime.Source,
+		})
+		issue := &ValidationIssue{Event: relayTime.Event, Round: relayTime.Round, Name: name, Team: relayTeam(relayTime), Source: relayTime.Source}
+		if relaySwimmers && len(relayTime.Swimmers) != 4 {
+			issues = append(issues, issue.with(CHECK_RELAY_LEGS, SEVERITY_WARNING, fmt.Sprintf("%d swimmers, expected 4", len(relayTime.Swimmers))))
+		}
+		for _, relaySwimmer := range relayTime.Swimmers {
+			if message := validateAge(relayTime.Event, relaySwimmer.Age); message != "" {
+				issues = append(issues, issue.with(CHECK_AGE_GROUP, SEVERITY_WARNING, relaySwimmer.Name+": "+message))
+			}
+			// a swimmer can only swim one leg in one relay of an event
+			key := fmt.Sprintf("%p|%s|%s", relayTime.Event, relayTime.Round, relaySwimmer.Name)
+			if other, ok := relayLegs[key]; ok {
+				message := fmt.Sprintf("%s also swims in relay %s", relaySwimmer.Name, strings.TrimSpace(other.TeamName+" "+other.RelayEntry))
+				issues = append(issues, issue.with(CHECK_DUPLICATE_SWIMMER, SEVERITY_ERROR, message))
+			}
+			relayLegs[key] = relayTime
+		}
+	}
+
+	for _, event := range result.Events {
+		for _, round := range rounds[event] {
+			issues = append(issues, validateEventRound(event, round, swims[event][round])...)
+		}
+	}
+
+	if options.Scoring != nil {
+		for _, mismatch := range options.Scoring.VerifyPoints(result) {
+			issues = append(issues, &ValidationIssue{
+				Check:    CHECK_POINTS,
+				Severity: SEVERITY_ERROR,
+				Event:    mismatch.Event,
+				Name:     mismatch.Name,
+				Team:     mismatch.Team,
+				Message:  fmt.Sprintf("%v points for place %d, expected %v (%s)", mismatch.PrintedPoints, mismatch.Place.Value, mismatch.ExpectedPoints, options.Scoring.Name),
+				Source:   mismatch.Source,
+			})
+		}
+	}
+	return issues
+}
+
+// validateEventRound checks the places, the times and the swimmers of one round of an event, in the order of the results
+func validateEventRound(event *Event, round string, swims []*validatedSwim) []*ValidationIssue {
+	issues := []*ValidationIssue{}
+	seen := map[string]bool{}
+	var previous *validatedSwim
+	previousTime := -1
+	expectedPlace := 1
+	for _, swim := range swims {
+		issue := &ValidationIssue{Event: event, Round: round, Name: swim.name, Team: swim.team, Source: swim.source}
+		key := swim.team + "|" + swim.name
+		if seen[key] {
+			issues = append(issues, issue.with(CHECK_DUPLICATE_SWIMMER, SEVERITY_ERROR, "the swimmer is already in the results of the event"))
+		}
+		seen[key] = true
+
+		// exhibition and unranked swims (DQ, NS, ...) don't take a place
+		if swim.place.Value <= 0 || swim.place.Unranked || swim.place.Exhibition {
+			continue
+		}
+		if previous != nil && swim.place.Value < previous.place.Value {
+			message := fmt.Sprintf("place %d after place %d", swim.place.Value, previous.place.Value)
+			issues = append(issues, issue.with(CHECK_PLACE_ORDER, SEVERITY_ERROR, message))
+		} else if previous == nil || swim.place.Value != previous.place.Value {
+			if swim.place.Value > expectedPlace {
+				message := fmt.Sprintf("place %d, expected place %d", swim.place.Value, expectedPlace)
+				issues = append(issues, issue.with(CHECK_PLACE_SKIPPED, SEVERITY_WARNING, message))
+			}
+		}
+		if previous == nil || swim.place.Value >= previous.place.Value {
+			// tied swims take the places they cover: 1, 2, 2, 4
+			expectedPlace = max(expectedPlace, swim.place.Value) + 1
+		}
+
+		if time, err := timeToHundredths(swim.time); err == nil {
+			if previousTime != -1 && time < previousTime && swim.place.Value > previous.place.Value {
+				message := fmt.Sprintf("%s (place %d) is faster than %s (place %d)", swim.time, swim.place.Value, previous.time, previous.place.Value)
+				issues = append(issues, issue.with(CHECK_TIME_ORDER, SEVERITY_ERROR, message))
+			}
+			previousTime = time
+		} else {
+			previousTime = -1
+		}
+		previous = swim
+	}
+	return issues
+}
+
+// validateAge returns why the age doesn't fit the age group of the event, or an empty string.
+// Ages that aren't a number (grades, years of birth) and unknown age groups aren't checked.
+func validateAge(event *Event, age string) string {
+	ageValue, err := strconv.Atoi(strings.TrimSpace(age))
+	if err != nil {
+		return ""
+	}
+	contains, err := ageGroupContains(event.AgeGroup, ageValue)
+	if err != nil || contains {
+		return ""
+	}
+	return fmt.Sprintf("age %d is not in age group %s", ageValue, event.AgeGroup)
+}
+
+// validateSplits returns why the split times don't add up to the final time, or an empty string.
+// Cumulative splits end with the final time, lap splits add up to the final time.
+func validateSplits(swimmerTime *SwimmerTime) string {
+	if len(swimmerTime.SplitTimes) == 0 {
+		return ""
+	}
+	final, err := timeToHundredths(swimmerTime.Time)
+	if err != nil {
+		return "" // DQ, NS, ...
+	}
+	splits := []int{}
+	cumulative := true
+	total := 0
+	for k, splitTime := range swimmerTime.SplitTimes {
+		split, err := timeToHundredths(splitTime)
+		if err != nil {
+			return ""
+		}
+		if k > 0 && split <= splits[k-1] {
+			cumulative = false
+		}
+		splits = append(splits, split)
+		total += split
+	}
+	last := splits[len(splits)-1]
+	switch {
+	case cumulative && last == final:
+		return ""
+	case total == final:
+		return ""
+	case cumulative:
+		return fmt.Sprintf("the last split %s is not the final time %s", swimmerTime.SplitTimes[len(splits)-1], swimmerTime.Time)
+	}
+	return fmt.Sprintf("the splits add up to %s, not the final time %s", formatHundredths(total), swimmerTime.Time)
+}
+
+func (v *ValidationIssue) with(check string, severity string, message string) *ValidationIssue {
+	issue := *v
+	issue.Check = check
+	issue.Severity = severity
+	issue.Message = message
+	return &issue
+}
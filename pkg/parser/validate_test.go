@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidate(t *testing.T) {
+	individual := &Event{Round: "1", AgeGroup: "11-12"}
+	relay := &Event{Round: "2", AgeGroup: "10 & under", Relay: true}
+	result := Result{
+		Events: []*Event{individual, relay},
+		Times: []*SwimmerTime{
+			{Event: individual, Place: Place{Value: 1}, Name: "A", TeamName: "T1", Age: "12", Time: "30.00", Points: 9, PointsPrinted: true, SplitTimes: []string{"14.50", "30.00"}},
+			{Event: individual, Place: Place{Value: 2, Tie: true}, Name: "B", TeamName: "T2", Age: "11", Time: "31.00", Points: 6.5, PointsPrinted: true, SplitTimes: []string{"15.00", "15.50"}},
+			{Event: individual, Place: Place{Value: 2, Tie: true}, Name: "C", TeamName: "T1", Age: "13", Time: "31.00", Points: 6.5, PointsPrinted: true},
+			{Event: individual, Place: Place{Value: 5}, Name: "D", TeamName: "T2", Age: "12", Time: "30.50", Points: 3, PointsPrinted: true, SplitTimes: []string{"15.00", "31.00"}},
+			{Event: individual, Place: Place{Value: 4}, Name: "E", TeamName: "T3", Age: "12", Time: "33.00", Points: 5, PointsPrinted: true},
+			{Event: individual, Place: Place{Unranked: true}, Name: "A", TeamName: "T1", Age: "12", Time: "DQ"},
+		},
+		RelayTimes: []*RelayTime{
+			{Event: relay, Place: Place{Value: 1}, TeamName: "T1", RelayEntry: "A", Time: "2:00.00", Points: 18, PointsPrinted: true, Swimmers: []*RelaySwimmer{
+				{Name: "F", Age: "10"}, {Name: "G", Age: "9"}, {Name: "H", Age: "10"}, {Name: "I", Age: "10"},
+			}},
+			{Event: relay, Place: Place{Value: 2}, TeamName: "T1", RelayEntry: "B", Time: "2:05.00", Points: 14, PointsPrinted: true, Swimmers: []*RelaySwimmer{
+				{Name: "J", Age: "11"}, {Name: "F", Age: "10"}, {Name: "K", Age: "9"},
+			}},
+		},
+	}
+	issues := Validate(result, ValidationOptions{Scoring: ScoringChampionship8})
+	type issue struct {
+		Check string
+		Name  string
+	}
+	got := []issue{}
+	for _, validationIssue := range issues {
+		got = append(got, issue{validationIssue.Check, validationIssue.Name})
+	}
+	expected := []issue{
+		{CHECK_SPLITS, "B"},
+		{CHECK_AGE_GROUP, "C"},
+		{CHECK_SPLITS, "D"},
+		{CHECK_RELAY_LEGS, "T1 B"},
+		{CHECK_AGE_GROUP, "T1 B"},
+		{CHECK_DUPLICATE_SWIMMER, "T1 B"},
+		{CHECK_PLACE_SKIPPED, "D"},
+		{CHECK_TIME_ORDER, "D"},
+		{CHECK_PLACE_ORDER, "E"},
+		{CHECK_DUPLICATE_SWIMMER, "A"},
+		{CHECK_POINTS, "D"},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidateSplits(t *testing.T) {
+	tests := []struct {
+		time       string
+		splitTimes []string
+		expected   string
+	}{
+		{"1:01.23", []string{"29.01", "1:01.23"}, ""},
+		{"1:01.23", []string{"29.01", "32.22"}, ""},
+		{"1:01.23", []string{"29.01", "1:01.50"}, "the last split 1:01.50 is not the final time 1:01.23"},
+		{"1:01.23", []string{"29.01", "28.22"}, "the splits add up to 57.23, not the final time 1:01.23"},
+		{"DQ", []string{"29.01", "28.22"}, ""},
+	}
+	for _, tt := range tests {
+		got := validateSplits(&SwimmerTime{Time: tt.time, SplitTimes: tt.splitTimes})
+		if got != tt.expected {
+			t.Fatalf("%s %v: got '%s', expected '%s'", tt.time, tt.splitTimes, got, tt.expected)
+		}
+	}
+}
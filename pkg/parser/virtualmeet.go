@@ -0,0 +1,204 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+type VirtualMeetOptions struct {
+	// teams taking part in the meet. When empty, every team found in the times is entered
+	Teams []string
+	// maximum entries per team per event (0 = unlimited)
+	IndividualEntries int
+	RelayEntries      int
+	// maximum individual events per swimmer (0 = unlimited). Events are filled in order
+	MaxIndividualEventsPerSwimmer int
+	Scoring                       *ScoringTable
+}
+
+type VirtualMeet struct {
+	// the hypothetical results: every swim is a copy of the original swim with the virtual place and points
+	Result     Result             `json:"result"`
+	TeamScores map[string]float64 `json:"teamScores"`
+}
+
+type virtualEntry struct {
+	swimmerTime *SwimmerTime
+	relayTime   *RelayTime
+	key         string
+	team        string
+	time        int
+}
+
+// SimulateMeet scores a hypothetical meet between teams. The times can come from one or many parsed results:
+// every team enters its best swims per event, within the entry limits, and the events are scored with the scoring table.
+func SimulateMeet(times []*SwimmerTime, relayTimes []*RelayTime, options VirtualMeetOptions) (*VirtualMeet, error) {
+	if options.Scoring == nil {
+		return nil, fmt.Errorf("no scoring table supplied")
+	}
+	events := []*Event{}
+	eventsByKey := map[string]*Event{}
+	entries := map[*Event][]*virtualEntry{}
+	eventFor := func(event *Event) *Event {
+		key := eventKey(event)
+		if virtualEvent, ok := eventsByKey[key]; ok {
+			return virtualEvent
+		}
+		virtualEvent := &Event{
+			Round:           strconv.Itoa(len(events) + 1),
+			Gender:          event.Gender,
+			AgeGroup:        event.AgeGroup,
+			Distance:        event.Distance,
+			Stroke:          event.Stroke,
+			Relay:           event.Relay,
+			QualifyingTimes: map[string]string{},
+		}
+		eventsByKey[key] = virtualEvent
+		events = append(events, virtualEvent)
+		return virtualEvent
+	}
+
+	// best time per swimmer per event
+	best := map[*Event]map[string]*virtualEntry{}
+	for _, swimmerTime := range times {
+		if swimmerTime.Event == nil || !virtualMeetTeam(options.Teams, swimmerTime.TeamName) {
+			continue
+		}
+		time, err := timeToHundredths(swimmerTime.Time)
+		if err != nil {
+			continue // DQ, NS, ...
+		}
+		event := eventFor(swimmerTime.Event)
+		if best[event] == nil {
+			best[event] = map[string]*virtualEntry{}
+		}
+		key := swimmerTime.TeamName + "|" + swimmerTime.Name
+		if current, ok := best[event][key]; !ok || time < current.time {
+			best[event][key] = &virtualEntry{swimmerTime: swimmerTime, key: key, team: swimmerTime.TeamName, time: time}
+		}
+	}
+	for _, relayTime := range relayTimes {
+		if relayTime.Event == nil || !virtualMeetTeam(options.Teams, relayTeam(relayTime)) && !virtualMeetTeam(options.Teams, relayTime.TeamName) {
+			continue
+		}
+		time, err := timeToHundredths(relayTime.Time)
+		if err != nil {
+			continue
+		}
+		event := eventFor(relayTime.Event)
+		if best[event] == nil {
+			best[event] = map[string]*virtualEntry{}
+		}
+		key := relayTeam(relayTime) + "|" + relayTime.RelayEntry
+		if current, ok := best[event][key]; !ok || time < current.time {
+			best[event][key] = &virtualEntry{relayTime: relayTime, key: key, team: relayTeam(relayTime), time: time}
+		}
+	}
+
+	// pick the entries per team
+	swimmerEvents := map[string]int{}
+	for _, event := range events {
+		candidates := []*virtualEntry{}
+		for _, entry := range best[event] {
+			candidates = append(candidates, entry)
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].time != candidates[j].time {
+				return candidates[i].time < candidates[j].time
+			}
+			return candidates[i].key < candidates[j].key
+		})
+		limit := options.IndividualEntries
+		if event.Relay {
+			limit = options.RelayEntries
+		}
+		teamEntries := map[string]int{}
+		for _, entry := range candidates {
+			if limit > 0 && teamEntries[entry.team] >= limit {
+				continue
+			}
+			if entry.swimmerTime != nil && options.MaxIndividualEventsPerSwimmer > 0 {
+				swimmer := entry.team + "|" + entry.swimmerTime.Name
+				if swimmerEvents[swimmer] >= options.MaxIndividualEventsPerSwimmer {
+					continue
+				}
+				swimmerEvents[swimmer]++
+			}
+			teamEntries[entry.team]++
+			entries[event] = append(entries[event], entry)
+		}
+	}
+
+	// place and score the entries
+	result := Result{
+		Events:        events,
+		Times:         []*SwimmerTime{},
+		RelayTimes:    []*RelayTime{},
+		ParseErrors:   []*ParseError{},
+		UnparsedLines: []*UnparsedLine{},
+	}
+	for _, event := range events {
+		place := Place{}
+		eventEntries := entries[event]
+		for k, entry := range eventEntries {
+			if k == 0 || entry.time != eventEntries[k-1].time {
+				place = Place{
+					Value: k + 1,
+					Tie:   k+1 < len(eventEntries) && entry.time == eventEntries[k+1].time,
+				}
+			}
+			// every swim of the virtual meet is a timed final, whatever the round of the best time was
+			if entry.swimmerTime != nil {
+				swimmerTime := *entry.swimmerTime
+				swimmerTime.Event = event
+				swimmerTime.Place = place
+				swimmerTime.Round = ROUND_TIMED_FINAL
+				swimmerTime.Heat = ""
+				swimmerTime.Lane = ""
+				swimmerTime.FinalsRound = ""
+				swimmerTime.PrelimTime = ""
+				swimmerTime.Uncertain = false
+				swimmerTime.Points = 0
+				swimmerTime.PointsPrinted = false
+				result.Times = append(result.Times, &swimmerTime)
+			} else {
+				relayTime := *entry.relayTime
+				relayTime.Event = event
+				relayTime.Place = place
+				relayTime.Round = ROUND_TIMED_FINAL
+				relayTime.Heat = ""
+				relayTime.Uncertain = false
+				relayTime.Points = 0
+				relayTime.PointsPrinted = false
+				result.RelayTimes = append(result.RelayTimes, &relayTime)
+			}
+		}
+	}
+	scored := options.Scoring.Score(result)
+	for _, swim := range scored {
+		if swim.SwimmerTime != nil {
+			swim.SwimmerTime.Points = swim.Points
+			swim.SwimmerTime.PointsPrinted = true
+		} else {
+			swim.RelayTime.Points = swim.Points
+			swim.RelayTime.PointsPrinted = true
+		}
+	}
+
+	return &VirtualMeet{
+		Result:     result,
+		TeamScores: TeamScores(scored),
+	}, nil
+}
+
+// eventKey identifies the same event across meets
+func eventKey(event *Event) string {
+	distance, course := parseCourse(event.Distance)
+	return fmt.Sprintf("%s|%s|%d%s|%s|%v", normalizeGender(event.Gender), event.AgeGroup, distance, course, normalizeStroke(event.Stroke), event.Relay)
+}
+
+func virtualMeetTeam(teams []string, team string) bool {
+	return len(teams) == 0 || slices.Contains(teams, team)
+}
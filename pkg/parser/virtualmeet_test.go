@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSimulateMeet(t *testing.T) {
+	meet1 := &Event{Round: "1", Gender: "girls", AgeGroup: "10 & under", Distance: "50 Yard", Stroke: "Freestyle"}
+	meet2 := &Event{Round: "7", Gender: "Girls", AgeGroup: "10 & under", Distance: "50 Yard", Stroke: "Free"}
+	relay := &Event{Round: "2", Gender: "girls", AgeGroup: "10 & under", Distance: "200 Yard", Stroke: "Freestyle", Relay: true}
+	times := []*SwimmerTime{
+		{Event: meet1, Name: "A", TeamName: "T1", Time: "35.00"},
+		{Event: meet2, Name: "A", TeamName: "T1", Time: "34.00"},
+		{Event: meet1, Name: "B", TeamName: "T1", Time: "34.50"},
+		{Event: meet1, Name: "C", TeamName: "T1", Time: "34.60"},
+		{Event: meet1, Name: "D", TeamName: "T2", Time: "34.50"},
+		{Event: meet1, Name: "E", TeamName: "T2", Time: "DQ"},
+		{Event: meet1, Name: "F", TeamName: "T3", Time: "30.00"},
+	}
+	relayTimes := []*RelayTime{
+		{Event: relay, TeamName: "T1", RelayEntry: "A", Time: "2:30.00"},
+		{Event: relay, TeamName: "T2", RelayEntry: "A", Time: "2:20.00"},
+		{Event: relay, TeamName: "T2", RelayEntry: "B", Time: "2:40.00"},
+	}
+	meet, err := SimulateMeet(times, relayTimes, VirtualMeetOptions{
+		Teams:             []string{"T1", "T2"},
+		IndividualEntries: 2,
+		RelayEntries:      1,
+		Scoring:           ScoringDualMeet,
+	})
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	if len(meet.Result.Events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(meet.Result.Events))
+	}
+	got := []string{}
+	for _, swimmerTime := range meet.Result.Times {
+		got = append(got, fmt.Sprintf("%s %s %s %v", swimmerTime.Place, swimmerTime.Name, swimmerTime.Time, swimmerTime.Points))
+	}
+	expected := []string{"1 A 34.00 6", "*2 B 34.50 3.5", "*2 D 34.50 3.5"}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+	if len(meet.Result.RelayTimes) != 2 {
+		t.Fatalf("expected 2 relays, got %d", len(meet.Result.RelayTimes))
+	}
+	if diff := cmp.Diff(map[string]float64{"T1": 13.5, "T2": 11.5}, meet.TeamScores); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSimulateMeetPrelims(t *testing.T) {
+	event := &Event{Round: "3", Gender: "boys", AgeGroup: "11-12", Distance: "50 Yard", Stroke: "Freestyle"}
+	times := []*SwimmerTime{
+		// the best time of A is from the prelims, the time of the final is slower
+		{Event: event, Name: "A", TeamName: "X", Round: ROUND_PRELIM, Heat: "2", Time: "28.00", FinalsRound: ROUND_FINAL, Uncertain: true},
+		{Event: event, Name: "A", TeamName: "X", Round: ROUND_FINAL, PrelimTime: "28.00", Time: "28.50"},
+		{Event: event, Name: "B", TeamName: "Y", Round: ROUND_FINAL, PrelimTime: "29.10", Time: "29.00"},
+	}
+	meet, err := SimulateMeet(times, nil, VirtualMeetOptions{
+		Teams:             []string{"X", "Y"},
+		IndividualEntries: 1,
+		Scoring:           ScoringDualMeet,
+	})
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	got := []string{}
+	for _, swimmerTime := range meet.Result.Times {
+		got = append(got, fmt.Sprintf("%s %s %q %q %q %s %v", swimmerTime.Place, swimmerTime.Name, swimmerTime.Round, swimmerTime.Heat, swimmerTime.PrelimTime, swimmerTime.Time, swimmerTime.Points))
+	}
+	expected := []string{`1 A "" "" "" 28.00 6`, `2 B "" "" "" 29.00 4`}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]float64{"X": 6, "Y": 4}, meet.TeamScores); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,242 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// team score table rows, one or more teams per line
+// line: 1 Lynchburg YMCA 33
+// line: 1. Nitro Swimming-ST 1,024.5     2. Mansfield Aquatic Club-NT 850
+var teamScoreRegex = regexp.MustCompile(`\*?\d+\.?\s+([^\d\s][^\d]*?)\s+[\d,]+(?:\.\d+)?`)
+
+// teamVocabulary is every team of a document, collected in a first pass over the lines. The second pass
+// splits the name and the team of a line at a team of the vocabulary when the spacing doesn't.
+type teamVocabulary struct {
+	// team as written on the lines (Nitro Swimming-ST), and the number of results of the team
+	teams map[string]int
+	// teams of the relay lines and the team score tables: these don't depend on the name/team boundary
+	confirmed map[string]bool
+	lscs      map[string]bool
+	// the teams, longest first
+	sorted []string
+}
+
+// collectTeamVocabulary collects the teams of the results of the first pass: the teams of the swimmer and relay times,
+// the LSCs and short team codes, and the teams of the team score tables
+func collectTeamVocabulary(lines []*textLine, fileType string, result Result) *teamVocabulary {
+	v := &teamVocabulary{
+		teams:     map[string]int{},
+		confirmed: map[string]bool{},
+		lscs:      map[string]bool{},
+	}
+	for _, swimmerTime := range result.Times {
+		v.add(swimmerTime.TeamName, swimmerTime.TeamLSC, false)
+	}
+	for _, relayTime := range result.RelayTimes {
+		v.add(relayTime.TeamName, relayTime.TeamLSC, true)
+		v.add(relayTime.TeamNameShort, "", true)
+	}
+
+	teamScores := false
+	for _, textLine := range lines {
+		line := textLine.Text
+		switch {
+		case strings.Contains(line, "Team Scores") || strings.Contains(line, "Team Rankings"):
+			teamScores = true
+		case isEvent(line, fileType):
+			teamScores = false
+		case teamScores:
+			for _, match := range teamScoreRegex.FindAllStringSubmatch(line, -1) {
+				team := strings.TrimSpace(match[1])
+				if index := strings.LastIndex(team, "-"); index != -1 {
+					v.add(team[0:index], team[index+1:], true)
+				} else {
+					v.add(team, "", true)
+				}
+			}
+		}
+	}
+
+	for team := range v.teams {
+		v.sorted = append(v.sorted, team)
+	}
+	sort.Slice(v.sorted, func(i, j int) bool {
+		if len(v.sorted[i]) != len(v.sorted[j]) {
+			return len(v.sorted[i]) > len(v.sorted[j])
+		}
+		return v.sorted[i] < v.sorted[j]
+	})
+	return v
+}
+
+// add adds a team as written on the lines: the team name, and the team name with the LSC
+func (v *teamVocabulary) add(teamName string, lsc string, confirmed bool) {
+	teamName = strings.TrimSpace(teamName)
+	if teamName == "" {
+		return
+	}
+	teams := []string{teamName}
+	if lsc != "" {
+		v.lscs[lsc] = true
+		teams = append(teams, teamName+"-"+lsc)
+	}
+	for _, team := range teams {
+		v.teams[team]++
+		v.confirmed[team] = v.confirmed[team] || confirmed
+	}
+}
+
+// known returns true for the teams found on a relay line, in a team score table or on more than one line
+func (v *teamVocabulary) known(team string) bool {
+	return v.confirmed[team] || v.teams[team] > 1
+}
+
+// teamAt returns the longest team of the vocabulary at the start of s, followed by a space
+func (v *teamVocabulary) teamAt(s string) string {
+	for _, team := range v.sorted {
+		if strings.HasPrefix(s, team+" ") {
+			return team
+		}
+	}
+	return ""
+}
+
+// splitLSC splits the team as written on the line in the team name and an LSC of the vocabulary
+// team: Mid-Atlantic Aquatics-MA
+func (v *teamVocabulary) splitLSC(team string) (string, string) {
+	if index := strings.LastIndex(team, "-"); index != -1 && v.lscs[team[index+1:]] {
+		return team[0:index], team[index+1:]
+	}
+	return team, ""
+}
+
+// lineTeam returns the team of a swimmer time as written on the line
+func lineTeam(swimmerTime *SwimmerTime) string {
+	if swimmerTime.TeamLSC != "" {
+		return swimmerTime.TeamName + "-" + swimmerTime.TeamLSC
+	}
+	return swimmerTime.TeamName
+}
+
+// settleLine returns the swimmer time of the line split at a team of the vocabulary, when the line couldn't be
+// parsed or when its team isn't found anywhere else in the document. The last return value is true when
+// the swimmer time of the vocabulary is used.
+func (v *teamVocabulary) settleLine(line string, fileType string, schema *columnSchema, swimmerTime *SwimmerTime, err error) (*SwimmerTime, error, bool) {
+	if err == nil && v.known(lineTeam(swimmerTime)) {
+		return swimmerTime, nil, false
+	}
+	settled, settleErr := v.processLine(line, fileType, schema)
+	if settleErr != nil {
+		return swimmerTime, err, false
+	}
+	if err == nil && (!v.known(lineTeam(settled)) || lineTeam(settled) == lineTeam(swimmerTime)) {
+		return swimmerTime, nil, false
+	}
+	return settled, nil, true
+}
+
+// processLine parses an individual result by splitting the line at a team of the vocabulary: the name and the
+// single word columns (age, grade, year of birth) are in front of the team, the times after the team
+// line: 1 Lastname, Firstname 14 Nation's Capital Swim Club 21.27 21.26
+func (v *teamVocabulary) processLine(line string, fileType string, schema *columnSchema) (*SwimmerTime, error) {
+	identity := []string{COLUMN_NAME, COLUMN_AGE, COLUMN_TEAM}
+	if fileType == FILETYPE_TYPE1 && schema != nil {
+		identity = schema.identityColumns()
+	}
+	if len(identity) < 2 || identity[0] != COLUMN_NAME || identity[len(identity)-1] != COLUMN_TEAM {
+		return nil, fmt.Errorf("the columns in front of the times don't start with the name and end with the team")
+	}
+	columns := identity[1 : len(identity)-1]
+	for _, column := range columns {
+		if columnValueRegex[column] == nil {
+			return nil, fmt.Errorf("column %s in between the name and the team isn't a single word", column)
+		}
+	}
+
+	line, exhibition := stripExhibitionMarker(line)
+	index1 := strings.Index(line, " ")
+	if index1 == -1 {
+		return nil, fmt.Errorf("couldn't determine place")
+	}
+	place, err := parsePlace(line[0:index1])
+	if err != nil {
+		return nil, fmt.Errorf("couldn't determine place: %s", err)
+	}
+	place.Exhibition = place.Exhibition || exhibition
+	line = line[index1+1:]
+
+	// line: Lastname, Firstname 14 Nation's Capital Swim Club 21.27 21.26
+	for k := 1; k < len(line); k++ {
+		if line[k-1] != ' ' {
+			continue
+		}
+		team := v.teamAt(line[k:])
+		if team == "" {
+			continue
+		}
+		words := strings.Fields(line[0:k])
+		if len(words) <= len(columns) {
+			continue
+		}
+		values := words[len(words)-len(columns):]
+		matches := true
+		for m, column := range columns {
+			matches = matches && columnValueRegex[column].MatchString(values[m])
+		}
+		if !matches {
+			continue
+		}
+		swimmer := &SwimmerTime{Place: place, Name: strings.Join(words[0:len(words)-len(columns)], " ")}
+		for m, column := range columns {
+			setColumn(swimmer, column, values[m])
+		}
+		// line: 21.27 21.26
+		times := line[k+len(team):]
+		if fileType == FILETYPE_TYPE2 {
+			swimmer.TeamName = team
+			err = processLineType2Times(swimmer, strings.TrimLeft(times, " "))
+		} else {
+			swimmer.TeamName, swimmer.TeamLSC = v.splitLSC(team)
+			err = processTimeColumns(swimmer, times, schema)
+		}
+		if err == nil {
+			return swimmer, nil
+		}
+	}
+	return nil, fmt.Errorf("no team of the document found on the line")
+}
+
+// processRelayLine parses a relay result by splitting the line at a team of the vocabulary,
+// when the team isn't followed by the spacing in front of the relay letter
+// line: 1 Lynchburg YMCA A 2:30.00 2:25.10 18
+func (v *teamVocabulary) processRelayLine(line string) (*RelayTime, error) {
+	relayTime := &RelayTime{
+		Swimmers: []*RelaySwimmer{},
+	}
+	line, exhibition := stripExhibitionMarker(line)
+	index1 := strings.Index(line, " ")
+	if index1 == -1 {
+		return nil, fmt.Errorf("place not found")
+	}
+	var err error
+	relayTime.Place, err = parsePlace(line[0:index1])
+	if err != nil {
+		return nil, fmt.Errorf("place not found: %s", err)
+	}
+	relayTime.Place.Exhibition = relayTime.Place.Exhibition || exhibition
+	line = line[index1+1:]
+	team := v.teamAt(line)
+	if team == "" {
+		return nil, fmt.Errorf("no team of the document found on the line")
+	}
+	relayTime.TeamName, relayTime.TeamLSC = v.splitLSC(team)
+	// line: A 2:30.00 2:25.10 18
+	err = processRelayLineType1Entry(relayTime, strings.TrimLeft(line[len(team):], " "))
+	if err != nil {
+		return nil, err
+	}
+	return relayTime, nil
+}
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePDFTextTeamVocabulary(t *testing.T) {
+	input := "Event 1  Girls 13-14 100 Yard Freestyle\n" +
+		"Name Age Team Seed Time Finals Time Points\n" +
+		"1 Lastname, Firstname  14 Nation's Capital Swim Club 58.00 57.21 9\n" +
+		"2 Lastname, Second 14 Nation's Capital Swim Club 59.00 58.40 7\n" +
+		"3 Lastname,  Third 13 Mid-Atlantic Aquatics-MA 1:00.00 59.10 6\n" +
+		"Event 2  Girls 13-14 200 Yard Freestyle Relay\n" +
+		"Team  Relay Seed Time Finals Time Points\n" +
+		"1 Nation's Capital Swim Club A 1:50.00 1:49.10 18\n" +
+		"\n" +
+		"Combined Team Scores - Girls\n" +
+		"1. Nation's Capital Swim Club 34     2. Mid-Atlantic Aquatics-MA 6\n"
+	res, err := parsePDFText(bytes.NewBufferString(input), Options{})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	for _, parseError := range res.ParseErrors {
+		t.Fatalf("parse error: %+v", parseError)
+	}
+	type swim struct {
+		Name, Age, TeamName, TeamLSC, Time string
+	}
+	got := []swim{}
+	for _, swimmerTime := range res.Times {
+		got = append(got, swim{swimmerTime.Name, swimmerTime.Age, swimmerTime.TeamName, swimmerTime.TeamLSC, swimmerTime.Time})
+	}
+	for _, relayTime := range res.RelayTimes {
+		got = append(got, swim{relayTime.RelayEntry, "", relayTime.TeamName, relayTime.TeamLSC, relayTime.Time})
+	}
+	expected := []swim{
+		{"Lastname, Firstname", "14", "Nation's Capital Swim Club", "", "57.21"},
+		{"Lastname, Second", "14", "Nation's Capital Swim Club", "", "58.40"},
+		{"Lastname, Third", "13", "Mid-Atlantic Aquatics", "MA", "59.10"},
+		{"A", "", "Nation's Capital Swim Club", "", "1:49.10"},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParsePDFTextTeamVocabularyType2(t *testing.T) {
+	input := "FileType: SwimTopia Meet Maestro\n" +
+		"#1 Mixed 6 & Under 25yd Freestyle\n" +
+		"Pl Name Age Team Seed Time\n" +
+		"1 Lastname, Firstname 6 Blue Dolphins 18.14 18.39\n" +
+		"#2 Mixed 6 & Under 100yd Freestyle Relay\n" +
+		"Pl Team Relay Seed Time\n" +
+		"1 Blue Dolphins A BD 1:26.68 1:25.10\n"
+	res, err := parsePDFText(bytes.NewBufferString(input), Options{})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.Times) != 1 {
+		t.Fatalf("got %d times, expected 1 (parse errors: %+v)", len(res.Times), res.ParseErrors)
+	}
+	if res.Times[0].Name != "Lastname, Firstname" || res.Times[0].Age != "6" || res.Times[0].TeamName != "Blue Dolphins" || res.Times[0].Time != "18.39" {
+		t.Fatalf("unexpected swimmer time: %+v", res.Times[0])
+	}
+}
+
+func TestCollectTeamVocabulary(t *testing.T) {
+	lines := []*textLine{
+		{Text: "Scores - Team Rankings - Through Event 12"},
+		{Text: "1. Nitro Swimming-ST 1,024.5     2. Lynchburg YMCA 850"},
+		{Text: "Event 13  Girls 10 & Under 50 Yard Freestyle"},
+		{Text: "1. Not A Team 12"},
+	}
+	result := Result{
+		Times:      []*SwimmerTime{{TeamName: "Heritage Swim"}, {TeamName: "Heritage Swim"}, {TeamName: "Forest Swim"}},
+		RelayTimes: []*RelayTime{{TeamName: "SwimTeam", TeamNameShort: "SWT"}},
+	}
+	vocabulary := collectTeamVocabulary(lines, FILETYPE_TYPE1, result)
+	tests := []struct {
+		team  string
+		known bool
+	}{
+		{"Nitro Swimming", true},
+		{"Nitro Swimming-ST", true},
+		{"Lynchburg YMCA", true},
+		{"Heritage Swim", true},
+		{"Forest Swim", false}, // only on its own line
+		{"SwimTeam", true},
+		{"SWT", true},
+		{"Not A Team", false},
+	}
+	for _, tt := range tests {
+		if vocabulary.known(tt.team) != tt.known {
+			t.Fatalf("%s: got known %t, expected %t", tt.team, !tt.known, tt.known)
+		}
+	}
+	if !vocabulary.lscs["ST"] {
+		t.Fatalf("expected LSC ST in the vocabulary")
+	}
+	if team := vocabulary.teamAt("Nitro Swimming-ST A 1:50.00"); team != "Nitro Swimming-ST" {
+		t.Fatalf("got team '%s', expected the longest team Nitro Swimming-ST", team)
+	}
+}
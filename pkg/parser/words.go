@@ -0,0 +1,304 @@
+package parser
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Word is a word of the pdf with its position, as written by the extractor in the .words.jsonl file
+type Word struct {
+	Page   int     `json:"page"`
+	Column int     `json:"column"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Text   string  `json:"text"`
+}
+
+// line of the document, with its page, column and line number when the input has them (0 when unknown).
+// Lines read from the words file keep the words with their positions
+type textLine struct {
+	Text       string
+	Words      []*Word
+	Page       int
+	Column     int
+	LineNumber int
+}
+
+// header column with the x position where it starts
+type wordColumn struct {
+	Column string
+	X      float64
+}
+
+// maximum difference in y for words on the same line
+const lineTolerance = 2.0
+
+// ParsePDFWords parses the words file (.words.jsonl) of the extractor. Individual and relay times are assigned to
+// the columns of the table header by their position, so names and team names with numbers or spacing parse correctly.
+func ParsePDFWords(filePath string) (Result, error) {
+	return ParsePDFWordsWithOptions(filePath, Options{})
+}
+
+// ParsePDFWordsWithOptions parses the words file of the extractor with the given options
+func ParsePDFWordsWithOptions(filePath string, options Options) (Result, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return Result{}, err
+	}
+	defer file.Close()
+	return parsePDFWords(file, options)
+}
+
+func parsePDFWords(reader io.Reader, options Options) (Result, error) {
+	words, fileType, err := readWords(reader)
+	if err != nil {
+		return Result{}, err
+	}
+	lines := wordLines(words)
+	if fileType != "" {
+		lines = append([]*textLine{{Text: "FileType: " + fileType}}, lines...)
+	}
+	for k, line := range lines {
+		line.LineNumber = k
+	}
+	return parseLines(lines, options)
+}
+
+// readWords reads the words, one json object per line. The first line can hold the file type:
+// {"fileType":"SwimTopia Meet Maestro"}
+func readWords(reader io.Reader) ([]*Word, string, error) {
+	words := []*Word{}
+	fileType := ""
+	scanner := bufio.NewScanner(reader)
+	for i := 0; scanner.Scan(); i++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if i == 0 && strings.HasPrefix(line, `{"fileType"`) {
+			header := struct {
+				FileType string `json:"fileType"`
+			}{}
+			if err := json.Unmarshal([]byte(line), &header); err != nil {
+				return words, fileType, fmt.Errorf("line %d: %s", i, err)
+			}
+			fileType = header.FileType
+			continue
+		}
+		word := &Word{}
+		if err := json.Unmarshal([]byte(line), word); err != nil {
+			return words, fileType, fmt.Errorf("line %d: %s", i, err)
+		}
+		words = append(words, word)
+	}
+	return words, fileType, scanner.Err()
+}
+
+// wordLines groups the words in lines, in the order of the text file: page by page, column by column, top to bottom.
+// The text of a line keeps the spacing between the words.
+func wordLines(words []*Word) []*textLine {
+	sorted := make([]*Word, len(words))
+	copy(sorted, words)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Page != sorted[j].Page {
+			return sorted[i].Page < sorted[j].Page
+		}
+		if sorted[i].Column != sorted[j].Column {
+			return sorted[i].Column < sorted[j].Column
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+
+	lines := []*textLine{}
+	var current *textLine
+	for k, word := range sorted {
+		if current == nil || word.Page != sorted[k-1].Page || word.Column != sorted[k-1].Column || word.Y-current.Words[0].Y > lineTolerance {
+			// the extractor numbers the columns from 0
+			current = &textLine{Page: word.Page, Column: word.Column + 1}
+			lines = append(lines, current)
+		}
+		current.Words = append(current.Words, word)
+	}
+	for _, line := range lines {
+		sort.SliceStable(line.Words, func(i, j int) bool { return line.Words[i].X < line.Words[j].X })
+		line.Text = wordsText(line.Words)
+	}
+	return lines
+}
+
+// wordsText joins the words, with as many spaces as fit in the gap between two words
+func wordsText(words []*Word) string {
+	text := ""
+	for k, word := range words {
+		if k > 0 {
+			previous := words[k-1]
+			spaces := 1
+			if characters := utf8.RuneCountInString(previous.Text); characters > 0 && previous.Width > 0 {
+				gap := word.X - (previous.X + previous.Width)
+				spaces = max(1, int(math.Round(gap/(previous.Width/float64(characters)))))
+			}
+			text += strings.Repeat(" ", spaces)
+		}
+		text += word.Text
+	}
+	return text
+}
+
+// headerWordColumns returns the columns of a header line with their position
+// line: Name Age Team Seed Time Finals Time Points
+func headerWordColumns(words []*Word) []*wordColumn {
+	columns := []*wordColumn{}
+	for k := 0; k < len(words); k++ {
+		word := strings.ToLower(words[k].Text)
+		if word == "ag" && k+1 < len(words) && words[k+1].Text == "e" {
+			k++ // Name Ag  e
+		}
+		column, ok := headerColumns[word]
+		if !ok {
+			continue
+		}
+		if word == "time" && len(columns) > 0 && isTimeColumn(columns[len(columns)-1].Column) {
+			continue // Seed Time, Finals Time
+		}
+		columns = append(columns, &wordColumn{Column: column, X: words[k].X})
+	}
+	return columns
+}
+
+// columnAt returns the column of a word: the last column that starts before the middle of the word.
+// Numbers and times are right aligned under their header, so the middle of the word is used instead of the start.
+func columnAt(columns []*wordColumn, word *Word) string {
+	middle := word.X + word.Width/2
+	column := ""
+	for _, wordColumn := range columns {
+		if wordColumn.X > middle {
+			break
+		}
+		column = wordColumn.Column
+	}
+	return column
+}
+
+// processLineWords parses an individual time with the words of the line: every word is assigned to the column
+// of the header above it. The time columns are parsed like a regular row.
+func processLineWords(words []*Word, columns []*wordColumn, schema *columnSchema) (*SwimmerTime, error) {
+	swimmer := &SwimmerTime{}
+	var err error
+	if len(words) == 0 {
+		return swimmer, fmt.Errorf("couldn't determine place")
+	}
+	// the place is always the first word
+	swimmer.Place, err = parsePlace(words[0].Text)
+	if err != nil {
+		return swimmer, fmt.Errorf("couldn't determine place: %s", err)
+	}
+
+	values := map[string]string{}
+	times := ""
+	for _, word := range words[1:] {
+		column := columnAt(columns, word)
+		switch column {
+		case COLUMN_NAME, COLUMN_AGE, COLUMN_YEAR_OF_BIRTH, COLUMN_GRADE, COLUMN_TEAM, COLUMN_HEAT, COLUMN_LANE:
+			if values[column] != "" {
+				values[column] += " "
+			}
+			values[column] += word.Text
+		case "":
+			return swimmer, fmt.Errorf("word in front of the name column: '%s'", word.Text)
+		default:
+			times += " " + word.Text
+		}
+	}
+	for _, column := range []string{COLUMN_NAME, COLUMN_AGE, COLUMN_YEAR_OF_BIRTH, COLUMN_GRADE, COLUMN_TEAM, COLUMN_HEAT, COLUMN_LANE} {
+		value, ok := values[column]
+		if !ok {
+			continue
+		}
+		if columnValueRegex[column] != nil && !columnValueRegex[column].MatchString(value) {
+			return swimmer, fmt.Errorf("unexpected value for column %s: '%s'", column, value)
+		}
+		setColumn(swimmer, column, value)
+	}
+	if swimmer.Name == "" {
+		return swimmer, fmt.Errorf("couldn't determine swimmer name")
+	}
+
+	// times: 2:14.96 x2:16.72 AG 9
+	times, exhibition := stripExhibitionMarker(times)
+	swimmer.Place.Exhibition = swimmer.Place.Exhibition || exhibition
+	err = processTimeColumns(swimmer, times, schema)
+	if err != nil {
+		return swimmer, err
+	}
+	return swimmer, nil
+}
+
+// processRelayLineWords parses a relay time with the words of the line: the words under the team and relay
+// headers are the team and the relay letter, the words after them are parsed like a regular row.
+// header: Team  Relay Seed Time Finals Time Points
+func processRelayLineWords(words []*Word, columns []*wordColumn) (*RelayTime, error) {
+	relayTime := &RelayTime{
+		Swimmers: []*RelaySwimmer{},
+	}
+	var err error
+	if len(words) == 0 {
+		return relayTime, fmt.Errorf("place not found")
+	}
+	// the place is always the first word
+	relayTime.Place, err = parsePlace(words[0].Text)
+	if err != nil {
+		return relayTime, fmt.Errorf("place not found: %s", err)
+	}
+
+	team := ""
+	times := ""
+	for _, word := range words[1:] {
+		switch columnAt(columns, word) {
+		case COLUMN_TEAM:
+			if team != "" {
+				team += " "
+			}
+			team += word.Text
+		case COLUMN_RELAY:
+			if relayTime.RelayEntry != "" {
+				return relayTime, fmt.Errorf("unexpected value for column %s: '%s %s'", COLUMN_RELAY, relayTime.RelayEntry, word.Text)
+			}
+			relayTime.RelayEntry = word.Text
+		case "":
+			return relayTime, fmt.Errorf("word in front of the team column: '%s'", word.Text)
+		default:
+			times += " " + word.Text
+		}
+	}
+	if team == "" {
+		return relayTime, fmt.Errorf("error while parsing team name")
+	}
+	if index := strings.Index(team, "-"); index != -1 {
+		relayTime.TeamName = team[0:index]
+		relayTime.TeamLSC = team[index+1:]
+	} else {
+		relayTime.TeamName = team
+	}
+
+	if relayTime.RelayEntry == "" {
+		return relayTime, fmt.Errorf("relay letter not found")
+	}
+
+	// times: 9:02.07 x8:43.46 TAGS 40
+	times, exhibition := stripExhibitionMarker(times)
+	relayTime.Place.Exhibition = relayTime.Place.Exhibition || exhibition
+	err = processRelayLineType1Entry(relayTime, relayTime.RelayEntry+times)
+	if err != nil {
+		return relayTime, err
+	}
+	return relayTime, nil
+}
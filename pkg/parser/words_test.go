@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// words of a line, every word is 5 points wide per character
+func testWords(page int, y float64, xs []float64, texts []string) []*Word {
+	words := []*Word{}
+	for k, text := range texts {
+		words = append(words, &Word{Page: page, X: xs[k], Y: y, Width: float64(len(text)) * 5, Height: 7, Text: text})
+	}
+	return words
+}
+
+func TestWordLines(t *testing.T) {
+	words := append(testWords(1, 20.5, []float64{10, 60}, []string{"Lastname,", "Firstname"}), testWords(1, 10, []float64{10, 70}, []string{"Event", "1"})...)
+	words = append(words, &Word{Page: 1, X: 125, Y: 21, Width: 10, Text: "14"})
+	lines := wordLines(words)
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, expected 2", len(lines))
+	}
+	expected := []string{"Event       1", "Lastname, Firstname    14"}
+	for k, line := range lines {
+		if line.Text != expected[k] {
+			t.Fatalf("got line '%s', expected '%s'", line.Text, expected[k])
+		}
+	}
+}
+
+func TestProcessLineWords(t *testing.T) {
+	header := testWords(1, 10, []float64{30, 140, 170, 260, 285, 320, 355, 390}, []string{"Name", "Age", "Team", "Seed", "Time", "Finals", "Time", "Points"})
+	columns := headerWordColumns(header)
+	tests := []struct {
+		words    []*Word
+		expected SwimmerTime
+	}{
+		{
+			// team name with a number, which looks like an age in the text
+			testWords(1, 20, []float64{10, 30, 100, 148, 170, 195, 270, 335}, []string{"---", "Lastname,", "Firstname", "9", "Team", "7", "NT", "DQ"}),
+			SwimmerTime{Place: Place{Unranked: true}, Name: "Lastname, Firstname", Age: "9", TeamName: "Team 7", SeedTime: "NT", Time: "DQ"},
+		},
+		{
+			testWords(1, 20, []float64{10, 30, 80, 145, 170, 270, 330}, []string{"3", "Lastname,", "Firstname", "10", "Lynchburg-VA", "33.10", "x32.54"}),
+			SwimmerTime{Place: Place{Value: 3, Exhibition: true}, Name: "Lastname, Firstname", Age: "10", TeamName: "Lynchburg", TeamLSC: "VA", SeedTime: "33.10", Time: "32.54"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(wordsText(tt.words), func(t *testing.T) {
+			parsed, err := processLineWords(tt.words, columns, nil)
+			if err != nil {
+				t.Fatalf("error: %s. SwimmerTime: %+v", err, parsed)
+			}
+			if diff := cmp.Diff(tt.expected, *parsed); diff != "" {
+				t.Fatalf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProcessRelayLineWords(t *testing.T) {
+	header := testWords(1, 10, []float64{30, 170, 260, 285, 320, 355, 390}, []string{"Team", "Relay", "Seed", "Time", "Finals", "Time", "Points"})
+	columns := headerWordColumns(header)
+	tests := []struct {
+		words    []*Word
+		expected RelayTime
+	}{
+		{
+			// team name with a number and without the spacing in front of the relay letter
+			testWords(1, 20, []float64{10, 30, 55, 65, 177, 262, 325, 400}, []string{"1", "Team", "7", "Aquatics", "A", "2:30.00", "2:25.10", "18"}),
+			RelayTime{Place: Place{Value: 1}, TeamName: "Team 7 Aquatics", RelayEntry: "A", SeedTime: "2:30.00", Time: "2:25.10", Points: 18, PointsPrinted: true, Swimmers: []*RelaySwimmer{}},
+		},
+		{
+			testWords(1, 20, []float64{10, 30, 80, 177, 262, 325}, []string{"2", "Lynchburg", "YMCA-VA", "B", "2:40.00", "x2:35.10"}),
+			RelayTime{Place: Place{Value: 2, Exhibition: true}, TeamName: "Lynchburg YMCA", TeamLSC: "VA", RelayEntry: "B", SeedTime: "2:40.00", Time: "2:35.10", Swimmers: []*RelaySwimmer{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(wordsText(tt.words), func(t *testing.T) {
+			parsed, err := processRelayLineWords(tt.words, columns)
+			if err != nil {
+				t.Fatalf("error: %s. RelayTime: %+v", err, parsed)
+			}
+			if diff := cmp.Diff(tt.expected, *parsed); diff != "" {
+				t.Fatalf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParsePDFWords(t *testing.T) {
+	input := `{"fileType":""}
+{"page":1,"column":0,"x":10,"y":10,"width":25,"height":7,"text":"Event"}
+{"page":1,"column":0,"x":40,"y":10,"width":5,"height":7,"text":"1"}
+{"page":1,"column":0,"x":55,"y":10,"width":25,"height":7,"text":"Girls"}
+{"page":1,"column":0,"x":85,"y":10,"width":15,"height":7,"text":"100"}
+{"page":1,"column":0,"x":100,"y":10,"width":20,"height":7,"text":"Yard"}
+{"page":1,"column":0,"x":125,"y":10,"width":45,"height":7,"text":"Freestyle"}
+{"page":1,"column":0,"x":30,"y":20,"width":20,"height":7,"text":"Name"}
+{"page":1,"column":0,"x":140,"y":20,"width":15,"height":7,"text":"Age"}
+{"page":1,"column":0,"x":170,"y":20,"width":20,"height":7,"text":"Team"}
+{"page":1,"column":0,"x":260,"y":20,"width":20,"height":7,"text":"Seed"}
+{"page":1,"column":0,"x":285,"y":20,"width":20,"height":7,"text":"Time"}
+{"page":1,"column":0,"x":320,"y":20,"width":30,"height":7,"text":"Finals"}
+{"page":1,"column":0,"x":355,"y":20,"width":20,"height":7,"text":"Time"}
+{"page":1,"column":0,"x":390,"y":20,"width":30,"height":7,"text":"Points"}
+{"page":1,"column":0,"x":10,"y":30,"width":5,"height":7,"text":"1"}
+{"page":1,"column":0,"x":30,"y":30,"width":45,"height":7,"text":"Lastname,"}
+{"page":1,"column":0,"x":80,"y":30,"width":25,"height":7,"text":"Mary"}
+{"page":1,"column":0,"x":110,"y":30,"width":10,"height":7,"text":"Jo"}
+{"page":1,"column":0,"x":145,"y":30,"width":10,"height":7,"text":"14"}
+{"page":1,"column":0,"x":170,"y":30,"width":20,"height":7,"text":"Swim"}
+{"page":1,"column":0,"x":195,"y":30,"width":20,"height":7,"text":"Club"}
+{"page":1,"column":0,"x":220,"y":30,"width":20,"height":7,"text":"2000"}
+{"page":1,"column":0,"x":265,"y":30,"width":35,"height":7,"text":"1:00.00"}
+{"page":1,"column":0,"x":330,"y":30,"width":25,"height":7,"text":"58.50"}
+{"page":1,"column":0,"x":405,"y":30,"width":10,"height":7,"text":"20"}
+`
+	res, err := parsePDFWords(bytes.NewBufferString(input), Options{})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.ParseErrors) > 0 {
+		t.Fatalf("got parse errors: %+v", res.ParseErrors[0])
+	}
+	if len(res.Events) != 1 || len(res.Times) != 1 {
+		t.Fatalf("got %d events and %d times, expected 1 and 1", len(res.Events), len(res.Times))
+	}
+	expected := SwimmerTime{
+		Event:         res.Events[0],
+		Place:         Place{Value: 1},
+		Name:          "Lastname, Mary Jo",
+		Age:           "14",
+		TeamName:      "Swim Club 2000",
+		SeedTime:      "1:00.00",
+		Time:          "58.50",
+		Points:        20,
+		PointsPrinted: true,
+		Source: &Source{
+			Page:       1,
+			Column:     1,
+			LineNumber: 2,
+			Line:       res.Times[0].Source.Line,
+		},
+	}
+	if diff := cmp.Diff(expected, *res.Times[0]); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}